@@ -7,10 +7,17 @@ import (
 	"io"
 
 	"github.com/ffddw/ripple/data"
+	"github.com/ffddw/ripple/keystore"
 	"github.com/ffddw/ripple/websockets"
 )
 
+// Action is a group of transactions signed by either Seed or the seed named
+// Key in a keystore, which is set by Resolve. The transactions are for the
+// account of the seed unless Account is set, in which case the seed must be
+// for a regular key of Account.
 type Action struct {
+	Key          string        `json:",omitempty"`
+	Account      *data.Account `json:",omitempty"`
 	Seed         data.Seed
 	Fee          data.Value
 	KeyType      data.KeyType
@@ -20,26 +27,26 @@ type Action struct {
 	Payments     []data.Payment
 }
 
-type actionFunc func(seed data.Seed, fee data.Value, keyType data.KeyType, tx data.Transaction, txType data.TransactionType) error
+type actionFunc func(a *Action, tx data.Transaction, txType data.TransactionType) error
 
 func (a *Action) each(f actionFunc) error {
 	for i := range a.AccountSets {
-		if err := f(a.Seed, a.Fee, a.KeyType, &a.AccountSets[i], data.ACCOUNT_SET); err != nil {
+		if err := f(a, &a.AccountSets[i], data.ACCOUNT_SET); err != nil {
 			return err
 		}
 	}
 	for i := range a.TrustSets {
-		if err := f(a.Seed, a.Fee, a.KeyType, &a.TrustSets[i], data.TRUST_SET); err != nil {
+		if err := f(a, &a.TrustSets[i], data.TRUST_SET); err != nil {
 			return err
 		}
 	}
 	for i := range a.OfferCreates {
-		if err := f(a.Seed, a.Fee, a.KeyType, &a.OfferCreates[i], data.OFFER_CREATE); err != nil {
+		if err := f(a, &a.OfferCreates[i], data.OFFER_CREATE); err != nil {
 			return err
 		}
 	}
 	for i := range a.Payments {
-		if err := f(a.Seed, a.Fee, a.KeyType, &a.Payments[i], data.PAYMENT); err != nil {
+		if err := f(a, &a.Payments[i], data.PAYMENT); err != nil {
 			return err
 		}
 	}
@@ -65,17 +72,52 @@ func (s ActionSlice) each(f actionFunc) error {
 	return nil
 }
 
+// Resolve sets the seed and key type of each action which names a key from
+// the unlocked keystore
+func (s ActionSlice) Resolve(k *keystore.Keystore) error {
+	for i := range s {
+		if s[i].Key == "" {
+			continue
+		}
+		entry, err := k.Get(s[i].Key)
+		if err != nil {
+			return err
+		}
+		s[i].Seed, s[i].KeyType = entry.Seed, entry.KeyType
+	}
+	return nil
+}
+
+// Prepare fills in and signs each transaction, after checking that rippled
+// would not reject it as malformed
 func (s ActionSlice) Prepare() error {
-	var prepare = func(seed data.Seed, fee data.Value, keyType data.KeyType, tx data.Transaction, txType data.TransactionType) error {
+	var zero data.Seed
+	for i := range s {
+		if s[i].Key != "" && s[i].Seed == zero {
+			return fmt.Errorf("Key %s has not been resolved from a keystore", s[i].Key)
+		}
+	}
+	var prepare = func(a *Action, tx data.Transaction, txType data.TransactionType) error {
 		var (
-			sequence uint32
-			key      = seed.Key(keyType)
+			sequence *uint32
+			key      = a.Seed.Key(a.KeyType)
 			base     = tx.GetBase()
 		)
+		// Ed25519 seeds have a single key rather than a family
+		if a.KeyType == data.ECDSA {
+			sequence = new(uint32)
+		}
 		base.TransactionType = txType
-		base.Fee = fee
-		base.Account = seed.AccountId(keyType, &sequence)
-		return data.Sign(tx, key, &sequence)
+		base.Fee = a.Fee
+		if a.Account != nil {
+			base.Account = *a.Account
+		} else {
+			base.Account = a.Seed.AccountId(a.KeyType, sequence)
+		}
+		if err := data.Validate(tx); err != nil {
+			return err
+		}
+		return data.Sign(tx, key, sequence)
 	}
 	return s.each(prepare)
 }
@@ -85,7 +127,7 @@ func (s ActionSlice) Submit(host string) error {
 	if err != nil {
 		return err
 	}
-	var submit = func(seed data.Seed, fee data.Value, keyType data.KeyType, tx data.Transaction, txType data.TransactionType) error {
+	var submit = func(a *Action, tx data.Transaction, txType data.TransactionType) error {
 		result, err := remote.Submit(tx)
 		if err != nil {
 			return err
@@ -100,7 +142,7 @@ func (s ActionSlice) Submit(host string) error {
 
 func (s ActionSlice) Count() int {
 	var count int
-	s.each(func(seed data.Seed, fee data.Value, keyType data.KeyType, tx data.Transaction, txType data.TransactionType) error {
+	s.each(func(a *Action, tx data.Transaction, txType data.TransactionType) error {
 		count++
 		return nil
 	})
@@ -2,7 +2,11 @@ package config
 
 import (
 	"os"
+	"strings"
 	"testing"
+
+	"github.com/ffddw/ripple/data"
+	"github.com/ffddw/ripple/keystore"
 )
 
 func TestParse(t *testing.T) {
@@ -20,3 +24,94 @@ func TestParse(t *testing.T) {
 	}
 	// t.Log(actions)
 }
+
+func TestResolve(t *testing.T) {
+	defer func(kdf keystore.KDF) { keystore.DefaultKDF = kdf }(keystore.DefaultKDF)
+	keystore.DefaultKDF.N = 1 << 10
+	seed, err := data.NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ks, err := keystore.New("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.Import("master", *seed, data.Ed25519); err != nil {
+		t.Fatal(err)
+	}
+	actions, err := Parse(strings.NewReader(`[{"key": "master", "fee": "10", "accountsets": [{"sequence": 1}]}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := actions.Prepare(); err == nil {
+		t.Fatal("Expected error for unresolved key")
+	}
+	if err := actions.Resolve(ks); err != nil {
+		t.Fatal(err)
+	}
+	if err := actions.Prepare(); err != nil {
+		t.Fatal(err)
+	}
+	if account := actions[0].AccountSets[0].Account; account != seed.AccountId(data.Ed25519, nil) {
+		t.Fatalf("Bad account: %s", account)
+	}
+}
+
+func TestRegularKey(t *testing.T) {
+	actions, err := Parse(strings.NewReader(`[{
+		"seed": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+		"keytype": "ed25519",
+		"account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh",
+		"fee": "10",
+		"accountsets": [{"sequence": 1}]
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := actions.Prepare(); err != nil {
+		t.Fatal(err)
+	}
+	tx := &actions[0].AccountSets[0]
+	if tx.Account != *actions[0].Account {
+		t.Fatalf("Bad account: %s", tx.Account)
+	}
+	regularKey := data.RegularKey(actions[0].Seed.AccountId(data.Ed25519, nil))
+	root := &data.AccountRoot{Account: actions[0].Account, RegularKey: &regularKey}
+	if authority, err := data.CheckAuthority(tx, root, nil); err != nil || authority != data.RegularKeyAuthority {
+		t.Fatalf("Expected RegularKey authority got: %s %v", authority, err)
+	}
+}
+
+func TestPrepareValidates(t *testing.T) {
+	actions, err := Parse(strings.NewReader(`[{
+		"seed": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb",
+		"fee": "10",
+		"offercreates": [{"sequence": 1, "takerpays": "1000", "takergets": "1000"}]
+	}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = actions.Prepare()
+	if verr, ok := err.(*data.ValidationError); !ok || verr.Result.String() != "temBAD_OFFER" {
+		t.Fatalf("Expected temBAD_OFFER got: %v", err)
+	}
+	if actions[0].OfferCreates[0].TxnSignature != nil {
+		t.Fatal("Invalid transaction was signed")
+	}
+}
+
+func TestNumericKeyType(t *testing.T) {
+	actions, err := Parse(strings.NewReader(`[
+		{"seed": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "keytype": 1, "fee": "10", "accountsets": [{"sequence": 1}]},
+		{"seed": "snoPBrXtMeMyMHUVTgbuqAfg1SUTb", "keytype": 0, "fee": "10", "accountsets": [{"sequence": 1}]}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if actions[0].KeyType != data.Ed25519 || actions[1].KeyType != data.ECDSA {
+		t.Fatalf("Bad key types: %s %s", actions[0].KeyType, actions[1].KeyType)
+	}
+	if _, err := Parse(strings.NewReader(`[{"keytype": 2}]`)); err == nil {
+		t.Fatal("Expected error for unknown key type")
+	}
+}
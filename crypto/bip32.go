@@ -0,0 +1,175 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+)
+
+// HardenedKeyStart is the first hardened child index in a derivation path
+const HardenedKeyStart uint32 = 0x80000000
+
+// BIP44Path returns the BIP44 derivation path of an XRP Ledger account as
+// used by hardware wallets for secp256k1 keys
+func BIP44Path(account, index uint32) string {
+	return fmt.Sprintf("m/44'/144'/%d'/0/%d", account, index)
+}
+
+// SLIP10Path returns the fully hardened equivalent of BIP44Path which
+// SLIP-10 requires for ed25519 keys
+func SLIP10Path(account, index uint32) string {
+	return fmt.Sprintf("m/44'/144'/%d'/0'/%d'", account, index)
+}
+
+// ParseDerivationPath parses a path such as m/44'/144'/0'/0/0 into child
+// indexes, with hardened indexes offset by HardenedKeyStart
+func ParseDerivationPath(path string) ([]uint32, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("Derivation path must start with m: %s", path)
+	}
+	var indexes []uint32
+	for _, part := range parts[1:] {
+		hardened := strings.HasSuffix(part, "'") || strings.HasSuffix(part, "H") || strings.HasSuffix(part, "h")
+		if hardened {
+			part = part[:len(part)-1]
+		}
+		index, err := strconv.ParseUint(part, 10, 32)
+		if err != nil || uint32(index) >= HardenedKeyStart {
+			return nil, fmt.Errorf("Bad derivation path index: %s in %s", part, path)
+		}
+		if hardened {
+			index += uint64(HardenedKeyStart)
+		}
+		indexes = append(indexes, uint32(index))
+	}
+	return indexes, nil
+}
+
+// extendedKey is a private key and chain code
+type extendedKey struct {
+	key, chainCode []byte
+}
+
+func newExtendedKey(hmacKey string, data ...[]byte) extendedKey {
+	mac := hmac.New(sha512.New, []byte(hmacKey))
+	for _, b := range data {
+		mac.Write(b)
+	}
+	sum := mac.Sum(nil)
+	return extendedKey{key: sum[:32], chainCode: sum[32:]}
+}
+
+func (k extendedKey) child(data ...[]byte) extendedKey {
+	return newExtendedKey(string(k.chainCode), data...)
+}
+
+func serialiseIndex(index uint32) []byte {
+	b := make([]byte, 4)
+	binary.BigEndian.PutUint32(b, index)
+	return b
+}
+
+func validSecp256k1Key(k []byte) bool {
+	n := new(big.Int).SetBytes(k)
+	return n.Sign() > 0 && n.Cmp(order) < 0
+}
+
+// deriveBIP32 derives a secp256k1 private key from a seed
+func deriveBIP32(seed []byte, path string) (*extendedKey, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	k := newExtendedKey("Bitcoin seed", seed)
+	if !validSecp256k1Key(k.key) {
+		return nil, fmt.Errorf("Seed gives invalid master key")
+	}
+	for _, index := range indexes {
+		var child extendedKey
+		if index >= HardenedKeyStart {
+			child = k.child([]byte{0}, k.key, serialiseIndex(index))
+		} else {
+			priv, _ := btcec.PrivKeyFromBytes(k.key)
+			child = k.child(priv.PubKey().SerializeCompressed(), serialiseIndex(index))
+		}
+		if !validSecp256k1Key(child.key) {
+			return nil, fmt.Errorf("Invalid child key at index: %d", index)
+		}
+		sum := new(big.Int).SetBytes(child.key)
+		sum.Add(sum, new(big.Int).SetBytes(k.key))
+		sum.Mod(sum, order)
+		if sum.Sign() == 0 {
+			return nil, fmt.Errorf("Invalid child key at index: %d", index)
+		}
+		child.key = sum.FillBytes(make([]byte, 32))
+		k = child
+	}
+	return &k, nil
+}
+
+// deriveSLIP10 derives an ed25519 private key seed from a seed
+func deriveSLIP10(seed []byte, path string) (*extendedKey, error) {
+	indexes, err := ParseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	k := newExtendedKey("ed25519 seed", seed)
+	for _, index := range indexes {
+		if index < HardenedKeyStart {
+			return nil, fmt.Errorf("SLIP-10 ed25519 derivation requires hardened indexes: %s", path)
+		}
+		k = k.child([]byte{0}, k.key, serialiseIndex(index))
+	}
+	return &k, nil
+}
+
+// bip32Key is a secp256k1 key derived along a BIP32 path. Unlike keys from
+// NewECDSAKey it has no account family, so sequences must be nil.
+type bip32Key struct {
+	priv *btcec.PrivateKey
+}
+
+func (k *bip32Key) Id(seq *uint32) []byte {
+	checkSequenceIsNil(seq)
+	return Sha256RipeMD160(k.Public(seq))
+}
+
+func (k *bip32Key) Public(seq *uint32) []byte {
+	checkSequenceIsNil(seq)
+	return k.priv.PubKey().SerializeCompressed()
+}
+
+func (k *bip32Key) Private(seq *uint32) []byte {
+	checkSequenceIsNil(seq)
+	b := k.priv.Key.Bytes()
+	return b[:]
+}
+
+// NewBIP32Key derives a secp256k1 key from a BIP39 seed along a BIP32 path
+// such as BIP44Path(0, 0)
+func NewBIP32Key(seed []byte, path string) (Key, error) {
+	k, err := deriveBIP32(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	key, _ := btcec.PrivKeyFromBytes(k.key)
+	return &bip32Key{priv: key}, nil
+}
+
+// NewSLIP10Key derives an ed25519 key from a BIP39 seed along a hardened
+// SLIP-10 path such as SLIP10Path(0, 0)
+func NewSLIP10Key(seed []byte, path string) (Key, error) {
+	k, err := deriveSLIP10(seed, path)
+	if err != nil {
+		return nil, err
+	}
+	return &ed25519key{priv: ed25519.NewKeyFromSeed(k.key)}, nil
+}
@@ -0,0 +1,95 @@
+package crypto
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+var bip39Index = make(map[string]int, len(bip39English))
+
+func init() {
+	for i, word := range bip39English {
+		bip39Index[word] = i
+	}
+}
+
+// NewMnemonic returns the BIP39 mnemonic for entropy of 16, 20, 24, 28 or
+// 32 bytes. If entropy is nil, 32 random bytes are used.
+func NewMnemonic(entropy []byte) (string, error) {
+	if entropy == nil {
+		entropy = make([]byte, 32)
+		if _, err := rand.Read(entropy); err != nil {
+			return "", err
+		}
+	}
+	n := len(entropy)
+	if n < 16 || n > 32 || n%4 != 0 {
+		return "", fmt.Errorf("Bad entropy length: %d", n)
+	}
+	// Entropy followed by n/32 bits of checksum, split into 11 bit words
+	checksumBits := uint(n / 4)
+	checksum := sha256.Sum256(entropy)
+	bits := new(big.Int).SetBytes(entropy)
+	bits.Lsh(bits, checksumBits)
+	bits.Or(bits, big.NewInt(int64(checksum[0]>>(8-checksumBits))))
+	words := make([]string, (n*8+int(checksumBits))/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = bip39English[new(big.Int).And(bits, mask).Int64()]
+		bits.Rsh(bits, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// MnemonicEntropy returns the entropy encoded by a BIP39 mnemonic, checking
+// the words and checksum are valid
+func MnemonicEntropy(mnemonic string) ([]byte, error) {
+	words := strings.Fields(mnemonic)
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, fmt.Errorf("Bad mnemonic length: %d words", len(words))
+	}
+	bits := new(big.Int)
+	for _, word := range words {
+		index, ok := bip39Index[word]
+		if !ok {
+			return nil, fmt.Errorf("Unknown mnemonic word: %s", word)
+		}
+		bits.Lsh(bits, 11)
+		bits.Or(bits, big.NewInt(int64(index)))
+	}
+	checksumBits := uint(len(words) / 3)
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1)).Int64()
+	bits.Rsh(bits, checksumBits)
+	entropy := make([]byte, len(words)*4/3)
+	bits.FillBytes(entropy)
+	expected := sha256.Sum256(entropy)
+	if int64(expected[0]>>(8-checksumBits)) != checksum {
+		return nil, fmt.Errorf("Bad mnemonic checksum")
+	}
+	return entropy, nil
+}
+
+// ValidateMnemonic returns an error if the mnemonic is not valid BIP39
+func ValidateMnemonic(mnemonic string) error {
+	_, err := MnemonicEntropy(mnemonic)
+	return err
+}
+
+// MnemonicSeed returns the 64 byte BIP39 seed for a valid mnemonic and
+// optional passphrase. The passphrase is used as given, so non-ASCII
+// passphrases must already be in NFKD form.
+func MnemonicSeed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	normalised := strings.Join(strings.Fields(mnemonic), " ")
+	return pbkdf2.Key([]byte(normalised), []byte("mnemonic"+passphrase), 2048, 64, sha512.New), nil
+}
@@ -49,3 +49,38 @@ func accountCheck(v interface{}) Hash {
 func (s *HashSuite) TestHashes(c *C) {
 	accountTests.Test(c)
 }
+
+var xAddressTests = []struct {
+	Address string
+	Tag     *uint32
+	Testnet bool
+	Encoded string
+}{
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", nil, false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(1), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV8xvjGQTYPiAx6gwDC"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(14), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtVoD9z4jAcBVsnb97sM"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(11747), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(4294967295), false, "XVLhHMPHU98es4dbozjVtdWzVrDjtV18pX8yuPT7y4xaEHi"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", nil, true, "TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE"},
+	{"rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf", tag(1), true, "TVE26TYGhfLC7tQDno7G8dGtxSkYQnSz1uDimDdPYXzSpyw"},
+}
+
+func tag(t uint32) *uint32 { return &t }
+
+func (s *HashSuite) TestXAddress(c *C) {
+	for _, test := range xAddressTests {
+		account := accountCheck(test.Address)
+		encoded, err := EncodeXAddress(account.Payload(), test.Tag, test.Testnet)
+		c.Assert(err, IsNil)
+		c.Check(encoded, Equals, test.Encoded)
+		id, tag, testnet, err := DecodeXAddress(test.Encoded)
+		c.Assert(err, IsNil)
+		c.Check(id, DeepEquals, account.Payload())
+		c.Check(tag, DeepEquals, test.Tag)
+		c.Check(testnet, Equals, test.Testnet)
+	}
+	_, _, _, err := DecodeXAddress("XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXc")
+	c.Check(err, ErrorMatches, "Bad Base58 checksum:.*")
+	_, _, _, err = DecodeXAddress(testAccounts["mtgox"].Account)
+	c.Check(err, ErrorMatches, "Bad X-address length:.*")
+}
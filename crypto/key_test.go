@@ -1,9 +1,13 @@
 package crypto
 
 import (
+	"crypto/ed25519"
 	"encoding/hex"
 	"fmt"
+	"strings"
 
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
 	. "gopkg.in/check.v1"
 )
 
@@ -107,3 +111,194 @@ func (s *KeySuite) TestEd25119(c *C) {
 	c.Check(checkSignature(c, key.Private(nil), other.Public(nil), hash, msg), Equals, false)
 	c.Check(checkSignature(c, other.Private(nil), key.Public(nil), hash, msg), Equals, false)
 }
+
+// Vectors from https://github.com/trezor/python-mnemonic/blob/master/vectors.json
+var mnemonicTests = []struct {
+	Entropy, Mnemonic, Seed string
+}{
+	{
+		"00000000000000000000000000000000",
+		"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+		"C55257C360C07C72029AEBC1B53C05ED0362ADA38EAD3E3E9EFA3708E53495531F09A6987599D18264C1E1C92F2CF141630C7A3C4AB7C81B2F001698E7463B04",
+	},
+	{
+		"7F7F7F7F7F7F7F7F7F7F7F7F7F7F7F7F",
+		"legal winner thank year wave sausage worth useful legal winner thank yellow",
+		"2E8905819B8723FE2C1D161860E5EE1830318DBF49A83BD451CFB8440C28BD6FA457FE1296106559A3C80937A1C1069BE3A3A5BD381EE6260E8D9739FCE1F607",
+	},
+}
+
+func (s *KeySuite) TestMnemonic(c *C) {
+	for _, test := range mnemonicTests {
+		mnemonic, err := NewMnemonic(h2b(test.Entropy))
+		c.Assert(err, IsNil)
+		c.Check(mnemonic, Equals, test.Mnemonic)
+		entropy, err := MnemonicEntropy(test.Mnemonic)
+		c.Assert(err, IsNil)
+		c.Check(b2h(entropy), Equals, test.Entropy)
+		seed, err := MnemonicSeed(test.Mnemonic, "TREZOR")
+		c.Assert(err, IsNil)
+		c.Check(b2h(seed), Equals, test.Seed)
+	}
+	mnemonic, err := NewMnemonic(nil)
+	c.Assert(err, IsNil)
+	c.Check(ValidateMnemonic(mnemonic), IsNil)
+	c.Check(ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon"), ErrorMatches, "Bad mnemonic checksum")
+	c.Check(ValidateMnemonic("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abut"), ErrorMatches, "Unknown mnemonic word: abut")
+	_, err = NewMnemonic(make([]byte, 17))
+	c.Check(err, ErrorMatches, "Bad entropy length: 17")
+}
+
+// Test vector 1 from BIP32 and SLIP-10
+func (s *KeySuite) TestHierarchicalDerivation(c *C) {
+	seed := h2b("000102030405060708090A0B0C0D0E0F")
+	for _, test := range []struct {
+		Path, Key, ChainCode string
+		ed25519              bool
+	}{
+		{"m", "E8F32E723DECF4051AEFAC8E2C93C9C5B214313817CDB01A1494B917C8436B35", "873DFF81C02F525623FD1FE5167EAC3A55A049DE3D314BB42EE227FFED37D508", false},
+		{"m/0'", "EDB2E14F9EE77D26DD93B4ECEDE8D16ED408CE149B6CD80B0715A2D911A0AFEA", "47FDACBD0F1097043B78C63C20C34EF4ED9A111D980047AD16282C7AE6236141", false},
+		{"m/0'/1", "3C6CB8D0F6A264C91EA8B5030FADAA8E538B020F0A387421A12DE9319DC93368", "2A7857631386BA23DACAC34180DD1983734E444FDBF774041578E9B6ADB37C19", false},
+		{"m", "2B4BE7F19EE27BBF30C667B642D5F4AA69FD169872F8FC3059C08EBAE2EB19E7", "90046A93DE5380A72B5E45010748567D5EA02BBF6522F979E05C0D8D8CA9FFFB", true},
+		{"m/0H", "68E0FE46DFB67E368C75379ACEC591DAD19DF3CDE26E63B93A8E704F1DADE7A3", "8B59AA11380B624E81507A27FEDDA59FEA6D0B779A778918A2FD3590E16E9C69", true},
+	} {
+		derive := deriveBIP32
+		if test.ed25519 {
+			derive = deriveSLIP10
+		}
+		k, err := derive(seed, test.Path)
+		c.Assert(err, IsNil, Commentf(test.Path))
+		c.Check(b2h(k.key), Equals, test.Key, Commentf(test.Path))
+		c.Check(b2h(k.chainCode), Equals, test.ChainCode, Commentf(test.Path))
+	}
+	_, err := NewSLIP10Key(seed, BIP44Path(0, 0))
+	c.Check(err, ErrorMatches, "SLIP-10 ed25519 derivation requires hardened indexes.*")
+	_, err = ParseDerivationPath("44'/144'")
+	c.Check(err, ErrorMatches, "Derivation path must start with m.*")
+
+	hash, msg := Sha512Half([]byte("message")), []byte("message")
+	for _, newKey := range []func([]byte, string) (Key, error){NewBIP32Key, NewSLIP10Key} {
+		key, err := newKey(seed, SLIP10Path(0, 0))
+		c.Assert(err, IsNil)
+		c.Check(checkSignature(c, key.Private(nil), key.Public(nil), hash, msg), Equals, true)
+	}
+}
+
+// Vectors from appendix B of RFC 1751
+func (s *KeySuite) TestRFC1751(c *C) {
+	for _, test := range []struct {
+		Key, Words string
+	}{
+		{"EB33F77EE73D4053", "TIDE ITCH SLOW REIN RULE MOT"},
+		{"CCAC2AED591056BE4F90FD441C534766", "RASH BUSH MILK LOOK BAD BRIM AVID GAFF BAIT ROT POD LOVE"},
+		{"EFF81F9BFBC65350920CDD7416DE8009", "TROD MUTE TAIL WARM CHAR KONG HAAG CITY BORE O TEAL AWL"},
+	} {
+		words, err := RFC1751Encode(h2b(test.Key))
+		c.Assert(err, IsNil)
+		c.Check(words, Equals, test.Words)
+		key, err := RFC1751Decode(strings.ToLower(test.Words))
+		c.Assert(err, IsNil)
+		c.Check(b2h(key), Equals, test.Key)
+	}
+	_, err := RFC1751Decode("TIDE ITCH SLOW REIN RULE MOE")
+	c.Check(err, ErrorMatches, "Bad RFC1751 parity.*")
+	_, err = RFC1751Decode("TIDE ITCH SLOW REIN RULE MOTE")
+	c.Check(err, ErrorMatches, "Unknown RFC1751 word: MOTE")
+	_, err = RFC1751Encode(make([]byte, 12))
+	c.Check(err, ErrorMatches, "Bad RFC1751 key length: 12")
+}
+
+// memoryToken is a Token holding one secp256k1 and one Ed25519 key
+type memoryToken struct {
+	ecdsa   *btcec.PrivateKey
+	ed25519 ed25519.PrivateKey
+}
+
+func (t *memoryToken) FindKey(label string) (uint, uint, error) {
+	switch label {
+	case "ecdsa":
+		return 1, 2, nil
+	case "ed25519":
+		return 3, 4, nil
+	default:
+		return 0, 0, fmt.Errorf("No key: %s", label)
+	}
+}
+
+func (t *memoryToken) ECPoint(public uint) ([]byte, error) {
+	if public == 2 {
+		return append([]byte{0x04, 65}, t.ecdsa.PubKey().SerializeUncompressed()...), nil
+	}
+	return t.ed25519.Public().(ed25519.PublicKey), nil
+}
+
+func (t *memoryToken) Sign(mechanism Mechanism, private uint, data []byte) ([]byte, error) {
+	if mechanism == CKM_EDDSA {
+		return ed25519.Sign(t.ed25519, data), nil
+	}
+	sig, err := ecdsa.SignCompact(t.ecdsa, data, false)
+	if err != nil {
+		return nil, err
+	}
+	// Return the high S form, which the signer must normalise
+	var s btcec.ModNScalar
+	s.SetByteSlice(sig[33:])
+	s.Negate()
+	b := s.Bytes()
+	return append(sig[1:33], b[:]...), nil
+}
+
+func (s *KeySuite) TestTokenSigner(c *C) {
+	token := &memoryToken{ed25519: ed25519.NewKeyFromSeed(make([]byte, 32))}
+	token.ecdsa, _ = btcec.PrivKeyFromBytes(h2b("E8F32E723DECF4051AEFAC8E2C93C9C5B214313817CDB01A1494B917C8436B35"))
+	msg := []byte("message")
+	hash := Sha512Half(msg)
+	for _, label := range []string{"ecdsa", "ed25519"} {
+		signer, err := NewTokenSigner(token, label)
+		c.Assert(err, IsNil)
+		public, err := signer.PublicKey()
+		c.Assert(err, IsNil)
+		sig, err := signer.Sign(hash, msg)
+		c.Assert(err, IsNil)
+		ok, err := Verify(public, hash, msg, sig)
+		c.Check(err, IsNil)
+		c.Check(ok, Equals, true, Commentf(label))
+	}
+	signer, err := NewTokenSigner(token, "ecdsa")
+	c.Assert(err, IsNil)
+	sig, err := signer.Sign(hash, msg)
+	c.Assert(err, IsNil)
+	parsed, err := ecdsa.ParseDERSignature(sig)
+	c.Assert(err, IsNil)
+	c.Check(parsed.Serialize(), DeepEquals, sig)
+	_, err = NewTokenSigner(token, "rsa")
+	c.Check(err, ErrorMatches, "No key: rsa")
+}
+
+func (s *KeySuite) TestSignMessage(c *C) {
+	seed, err := GenerateFamilySeed("masterpassphrase")
+	c.Assert(err, IsNil)
+	ecdsaKey, err := NewECDSAKey(seed.Payload())
+	c.Assert(err, IsNil)
+	edKey, err := NewEd25519Key(seed.Payload())
+	c.Assert(err, IsNil)
+	var zero uint32
+	msg := []byte("Challenge: 42")
+	for _, test := range []struct {
+		key      Key
+		sequence *uint32
+	}{{ecdsaKey, &zero}, {edKey, nil}} {
+		sig, err := SignMessage(NewKeySigner(test.key, test.sequence), msg)
+		c.Assert(err, IsNil)
+		public := test.key.Public(test.sequence)
+		id := Sha256RipeMD160(public)
+		c.Check(VerifyMessageFor(id, public, msg, sig), IsNil)
+		c.Check(VerifyMessageFor(id, public, []byte("Challenge: 43"), sig), ErrorMatches, "Bad message signature")
+		c.Check(VerifyMessageFor(make([]byte, 20), public, msg, sig), ErrorMatches, "Public key is not the master key of the account")
+
+		// A message signature is not a signature of the bare message
+		ok, err := Verify(public, Sha512Half(msg), msg, sig)
+		c.Check(err, IsNil)
+		c.Check(ok, Equals, false)
+	}
+}
@@ -0,0 +1,54 @@
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+)
+
+// MessagePrefix starts the data signed by SignMessage. Every other kind of
+// signed data starts with a 4 byte hash prefix of upper case letters and a
+// zero byte, so a signed message can never be replayed as a transaction or
+// validation.
+const MessagePrefix = "XRPL Signed Message:\n"
+
+// messageData returns the prefix, the decimal length of the message, a new
+// line and the message, along with its SHA-512Half
+func messageData(message []byte) ([]byte, []byte) {
+	msg := []byte(MessagePrefix + strconv.Itoa(len(message)) + "\n")
+	msg = append(msg, message...)
+	return Sha512Half(msg), msg
+}
+
+// SignMessage signs an arbitrary message, such as a challenge proving
+// ownership of an account, with a secp256k1 or Ed25519 key
+func SignMessage(signer Signer, message []byte) ([]byte, error) {
+	hash, msg := messageData(message)
+	return signer.Sign(hash, msg)
+}
+
+// VerifyMessage checks a signature made by SignMessage
+func VerifyMessage(publicKey, message, signature []byte) (bool, error) {
+	if len(publicKey) == 0 {
+		return false, fmt.Errorf("Empty public key")
+	}
+	hash, msg := messageData(message)
+	return Verify(publicKey, hash, msg, signature)
+}
+
+// VerifyMessageFor checks a signature made by SignMessage and that the
+// public key is the master key of the account id
+func VerifyMessageFor(accountId, publicKey, message, signature []byte) error {
+	if !bytes.Equal(Sha256RipeMD160(publicKey), accountId) {
+		return fmt.Errorf("Public key is not the master key of the account")
+	}
+	ok, err := VerifyMessage(publicKey, message, signature)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return fmt.Errorf("Bad message signature")
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,76 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+var rfc1751Index = make(map[string]uint64, len(rfc1751Words))
+
+func init() {
+	for i, word := range rfc1751Words {
+		rfc1751Index[word] = uint64(i)
+	}
+}
+
+// rfc1751Parity is the sum of the 2 bit pairs of a block, modulo 4
+func rfc1751Parity(block uint64) uint64 {
+	var parity uint64
+	for i := 0; i < 64; i += 2 {
+		parity += block >> i & 3
+	}
+	return parity & 3
+}
+
+// RFC1751Encode returns the RFC 1751 words for a key whose length is a
+// multiple of 8 bytes. Each 8 byte block becomes 6 words, the 64 bits of the
+// block followed by 2 bits of parity, split into 11 bit word indexes.
+func RFC1751Encode(key []byte) (string, error) {
+	if len(key) == 0 || len(key)%8 != 0 {
+		return "", fmt.Errorf("Bad RFC1751 key length: %d", len(key))
+	}
+	words := make([]string, 0, len(key)/8*6)
+	for i := 0; i < len(key); i += 8 {
+		block := binary.BigEndian.Uint64(key[i:])
+		for shift := 53; shift > 0; shift -= 11 {
+			words = append(words, rfc1751Words[block>>shift&0x7ff])
+		}
+		words = append(words, rfc1751Words[block&0x1ff<<2|rfc1751Parity(block)])
+	}
+	return strings.Join(words, " "), nil
+}
+
+// rfc1751Standard converts a word to the dictionary form, correcting the
+// common transcription errors listed in RFC 1751
+func rfc1751Standard(word string) string {
+	return strings.NewReplacer("1", "L", "0", "O", "5", "S").Replace(strings.ToUpper(word))
+}
+
+// RFC1751Decode returns the key encoded by RFC 1751 words, checking the
+// words and the parity of each block are valid
+func RFC1751Decode(s string) ([]byte, error) {
+	words := strings.Fields(s)
+	if len(words) == 0 || len(words)%6 != 0 {
+		return nil, fmt.Errorf("Bad RFC1751 length: %d words", len(words))
+	}
+	key := make([]byte, len(words)/6*8)
+	for i := 0; i < len(words); i += 6 {
+		var bits uint64
+		for _, word := range words[i : i+6] {
+			index, ok := rfc1751Index[rfc1751Standard(word)]
+			if !ok {
+				return nil, fmt.Errorf("Unknown RFC1751 word: %s", word)
+			}
+			bits = bits<<11 | index
+		}
+		// bits holds the 66 bits of the block and parity, less the top 2
+		// bits of the block which are restored from the first word
+		block := uint64(rfc1751Index[rfc1751Standard(words[i])])>>9<<62 | bits>>2
+		if bits&3 != rfc1751Parity(block) {
+			return nil, fmt.Errorf("Bad RFC1751 parity: %s", strings.Join(words[i:i+6], " "))
+		}
+		binary.BigEndian.PutUint64(key[i/6*8:], block)
+	}
+	return key, nil
+}
@@ -0,0 +1,27 @@
+package crypto
+
+// Signer signs with a key whose private part need not be held in this
+// process. hash is the SHA-512Half of msg: ECDSA signers sign the hash and
+// Ed25519 signers sign msg.
+type Signer interface {
+	PublicKey() ([]byte, error)
+	Sign(hash, msg []byte) ([]byte, error)
+}
+
+type keySigner struct {
+	key      Key
+	sequence *uint32
+}
+
+// NewKeySigner returns a Signer for a key held in memory
+func NewKeySigner(key Key, sequence *uint32) Signer {
+	return &keySigner{key: key, sequence: sequence}
+}
+
+func (s *keySigner) PublicKey() ([]byte, error) {
+	return s.key.Public(s.sequence), nil
+}
+
+func (s *keySigner) Sign(hash, msg []byte) ([]byte, error) {
+	return Sign(s.key.Private(s.sequence), hash, msg)
+}
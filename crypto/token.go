@@ -0,0 +1,97 @@
+package crypto
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/btcsuite/btcd/btcec/v2/ecdsa"
+)
+
+// Mechanism is a PKCS#11 signing mechanism
+type Mechanism uint
+
+const (
+	CKM_ECDSA Mechanism = 0x1041
+	CKM_EDDSA Mechanism = 0x1057
+)
+
+// Token is the part of a PKCS#11 session needed to sign with a key held by a
+// hardware security module or other token. It is small enough to implement
+// with any PKCS#11 binding, so this package does not depend on cgo.
+type Token interface {
+	// FindKey returns the handles of the private and public keys with the
+	// CKA_LABEL
+	FindKey(label string) (private, public uint, err error)
+	// ECPoint returns the CKA_EC_POINT of a public key
+	ECPoint(public uint) ([]byte, error)
+	// Sign calls C_SignInit and C_Sign. CKM_ECDSA signatures are the 64 byte
+	// concatenation of r and s.
+	Sign(mechanism Mechanism, private uint, data []byte) ([]byte, error)
+}
+
+type tokenSigner struct {
+	token     Token
+	private   uint
+	public    []byte
+	mechanism Mechanism
+}
+
+// NewTokenSigner returns a Signer for the secp256k1 or Ed25519 key pair with
+// the label in the token
+func NewTokenSigner(token Token, label string) (Signer, error) {
+	private, public, err := token.FindKey(label)
+	if err != nil {
+		return nil, err
+	}
+	point, err := token.ECPoint(public)
+	if err != nil {
+		return nil, err
+	}
+	s := &tokenSigner{token: token, private: private}
+	// CKA_EC_POINT should be a DER octet string, but some tokens give the
+	// raw point. The lengths of the two forms do not overlap.
+	switch len(point) {
+	case 2 + 65, 2 + 33, 2 + ed25519.PublicKeySize:
+		if point[0] != 0x04 || int(point[1]) != len(point)-2 {
+			return nil, fmt.Errorf("Bad EC point for key: %s", label)
+		}
+		point = point[2:]
+	}
+	switch len(point) {
+	case 65, 33:
+		pub, err := btcec.ParsePubKey(point)
+		if err != nil {
+			return nil, fmt.Errorf("Bad EC point for key: %s: %s", label, err)
+		}
+		s.public, s.mechanism = pub.SerializeCompressed(), CKM_ECDSA
+	case ed25519.PublicKeySize:
+		s.public, s.mechanism = append([]byte{0xED}, point...), CKM_EDDSA
+	default:
+		return nil, fmt.Errorf("Unknown EC point length for key: %s: %d", label, len(point))
+	}
+	return s, nil
+}
+
+func (s *tokenSigner) PublicKey() ([]byte, error) {
+	return s.public, nil
+}
+
+func (s *tokenSigner) Sign(hash, msg []byte) ([]byte, error) {
+	if s.mechanism == CKM_EDDSA {
+		return s.token.Sign(CKM_EDDSA, s.private, msg)
+	}
+	sig, err := s.token.Sign(CKM_ECDSA, s.private, hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(sig) != 64 {
+		return nil, fmt.Errorf("Wrong ECDSA signature length: %d", len(sig))
+	}
+	var r, sv btcec.ModNScalar
+	if r.SetByteSlice(sig[:32]) || sv.SetByteSlice(sig[32:]) {
+		return nil, fmt.Errorf("ECDSA signature overflows curve order")
+	}
+	// Serialize gives the canonical DER form with a low S value
+	return ecdsa.NewSignature(&r, &sv).Serialize(), nil
+}
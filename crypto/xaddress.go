@@ -0,0 +1,81 @@
+package crypto
+
+import (
+	"encoding/binary"
+	"fmt"
+)
+
+// X-addresses combine an account id, an optional destination tag and the
+// network into a single string, see
+// https://github.com/XRPLF/XRPL-Standards/tree/master/XLS-0005-tagged-addresses
+var (
+	xAddressMainnet = [2]byte{0x05, 0x44}
+	xAddressTestnet = [2]byte{0x04, 0x93}
+)
+
+const xAddressLength = 31
+
+// IsXAddress returns true if s has the leading character of a mainnet or
+// testnet X-address. It does not check that s is valid.
+func IsXAddress(s string) bool {
+	return len(s) > 0 && (s[0] == 'X' || s[0] == 'T')
+}
+
+// EncodeXAddress encodes a 20 byte account id and optional destination tag
+// as an X-address for mainnet or testnet
+func EncodeXAddress(accountId []byte, tag *uint32, testnet bool) (string, error) {
+	if len(accountId) != 20 {
+		return "", fmt.Errorf("Account id is wrong size, expected: 20 got: %d", len(accountId))
+	}
+	b := make([]byte, xAddressLength)
+	prefix := xAddressMainnet
+	if testnet {
+		prefix = xAddressTestnet
+	}
+	copy(b, prefix[:])
+	copy(b[2:], accountId)
+	if tag != nil {
+		b[22] = 1
+		binary.LittleEndian.PutUint32(b[23:], *tag)
+	}
+	return Base58Encode(b, ALPHABET), nil
+}
+
+// DecodeXAddress returns the account id, the destination tag, if any, and
+// whether the X-address is for testnet
+func DecodeXAddress(s string) ([]byte, *uint32, bool, error) {
+	decoded, err := Base58Decode(s, ALPHABET)
+	if err != nil {
+		return nil, nil, false, err
+	}
+	b := decoded[:len(decoded)-4]
+	if len(b) != xAddressLength {
+		return nil, nil, false, fmt.Errorf("Bad X-address length: %s", s)
+	}
+	var testnet bool
+	switch [2]byte{b[0], b[1]} {
+	case xAddressMainnet:
+	case xAddressTestnet:
+		testnet = true
+	default:
+		return nil, nil, false, fmt.Errorf("Bad X-address prefix: %s", s)
+	}
+	for _, reserved := range b[27:] {
+		if reserved != 0 {
+			return nil, nil, false, fmt.Errorf("Unsupported 64 bit X-address tag: %s", s)
+		}
+	}
+	var tag *uint32
+	switch b[22] {
+	case 0:
+		if binary.LittleEndian.Uint32(b[23:]) != 0 {
+			return nil, nil, false, fmt.Errorf("Bad X-address tag: %s", s)
+		}
+	case 1:
+		tag = new(uint32)
+		*tag = binary.LittleEndian.Uint32(b[23:])
+	default:
+		return nil, nil, false, fmt.Errorf("Bad X-address flags: %s", s)
+	}
+	return append([]byte(nil), b[2:22]...), tag, testnet, nil
+}
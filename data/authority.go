@@ -0,0 +1,92 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+// Authority is the reason a key may sign for an account
+type Authority uint8
+
+const (
+	MasterKeyAuthority Authority = iota + 1
+	RegularKeyAuthority
+	SignerListAuthority
+)
+
+func (a Authority) String() string {
+	switch a {
+	case MasterKeyAuthority:
+		return "MasterKey"
+	case RegularKeyAuthority:
+		return "RegularKey"
+	case SignerListAuthority:
+		return "SignerList"
+	default:
+		return "Unknown"
+	}
+}
+
+// keyAuthority returns the authority of the key with id to sign for the
+// account of root. A nil root is an account not yet in the ledger, which
+// can only sign with its master key.
+func keyAuthority(id []byte, account Account, root *AccountRoot) (Authority, error) {
+	var keyId Account
+	copy(keyId[:], id)
+	switch {
+	case keyId.Equals(account):
+		if root != nil && root.Flags != nil && *root.Flags&LsDisableMaster != 0 {
+			return 0, fmt.Errorf("Master key of %s is disabled", account)
+		}
+		return MasterKeyAuthority, nil
+	case root != nil && root.RegularKey != nil && Account(*root.RegularKey).Equals(keyId):
+		return RegularKeyAuthority, nil
+	default:
+		return 0, fmt.Errorf("Key %s is not authorised to sign for %s", keyId, account)
+	}
+}
+
+// CheckAuthority verifies the signatures of tx and reports whether the key
+// which signed it may sign for the account of root, which must be the
+// transaction's Account. A multisigned transaction is checked against list,
+// and signers' keys against the AccountRoots in signerRoots. A signer without
+// an AccountRoot must sign with its master key.
+func CheckAuthority(tx Transaction, root *AccountRoot, list *SignerList, signerRoots ...*AccountRoot) (Authority, error) {
+	base := tx.GetBase()
+	switch {
+	case root == nil:
+		return 0, fmt.Errorf("No AccountRoot for %s", base.Account)
+	case root.Account == nil || !root.Account.Equals(base.Account):
+		return 0, fmt.Errorf("AccountRoot is not for %s", base.Account)
+	}
+	if base.SigningPubKey != nil && !base.SigningPubKey.IsZero() {
+		ok, err := CheckSignature(tx)
+		switch {
+		case err != nil:
+			return 0, err
+		case !ok:
+			return 0, fmt.Errorf("Bad signature")
+		}
+		return keyAuthority(crypto.Sha256RipeMD160(base.SigningPubKey.Bytes()), base.Account, root)
+	}
+	if list == nil {
+		return 0, fmt.Errorf("Multisigned transaction for %s has no SignerList", base.Account)
+	}
+	if _, err := CheckQuorum(tx.(MultiSignable), list); err != nil {
+		return 0, err
+	}
+	roots := make(map[Account]*AccountRoot)
+	for _, signerRoot := range signerRoots {
+		if signerRoot.Account != nil {
+			roots[*signerRoot.Account] = signerRoot
+		}
+	}
+	for _, signer := range base.Signers {
+		id := crypto.Sha256RipeMD160(signer.Signer.SigningPubKey.Bytes())
+		if _, err := keyAuthority(id, signer.Signer.Account, roots[signer.Signer.Account]); err != nil {
+			return 0, err
+		}
+	}
+	return SignerListAuthority, nil
+}
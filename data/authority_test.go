@@ -0,0 +1,74 @@
+package data
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+func TestCheckAuthority(t *testing.T) {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sequence uint32
+	master := seed.AccountId(ECDSA, &sequence)
+	regular := seed.AccountId(Ed25519, nil)
+	sign := func(key crypto.Key, sequence *uint32) *Payment {
+		payment := newMultiSignPayment(t)
+		payment.Account = master
+		if err := Sign(payment, key, sequence); err != nil {
+			t.Fatal(err)
+		}
+		return payment
+	}
+	disabled := LsDisableMaster
+	regularKey := RegularKey(regular)
+	for _, test := range []struct {
+		description string
+		tx          *Payment
+		root        AccountRoot
+		authority   Authority
+		err         string
+	}{
+		{"master", sign(seed.Key(ECDSA), &sequence), AccountRoot{}, MasterKeyAuthority, ""},
+		{"master disabled", sign(seed.Key(ECDSA), &sequence), AccountRoot{Flags: &disabled, RegularKey: &regularKey}, 0, "Master key of .* is disabled"},
+		{"regular", sign(seed.Key(Ed25519), nil), AccountRoot{Flags: &disabled, RegularKey: &regularKey}, RegularKeyAuthority, ""},
+		{"no regular key", sign(seed.Key(Ed25519), nil), AccountRoot{}, 0, "Key .* is not authorised to sign for .*"},
+	} {
+		test.root.Account = &master
+		authority, err := CheckAuthority(test.tx, &test.root, nil)
+		if test.err == "" && err != nil {
+			t.Fatalf("%s: %s", test.description, err)
+		}
+		if test.err != "" && (err == nil || !regexp.MustCompile(test.err).MatchString(err.Error())) {
+			t.Fatalf("%s: expected error: %s got: %v", test.description, test.err, err)
+		}
+		if authority != test.authority {
+			t.Fatalf("%s: expected %s got: %s", test.description, test.authority, authority)
+		}
+	}
+	if _, err := CheckAuthority(sign(seed.Key(ECDSA), &sequence), nil, nil); err == nil {
+		t.Fatal("expected an error without an AccountRoot")
+	}
+
+	// Multisigned by the master key of a signer whose master key is disabled
+	payment := newMultiSignPayment(t)
+	if err := AddSigner(payment, crypto.NewKeySigner(seed.Key(ECDSA), &sequence), master); err != nil {
+		t.Fatal(err)
+	}
+	one := uint16(1)
+	quorum := uint32(1)
+	list := &SignerList{SignerQuorum: &quorum, SignerEntries: []SignerEntry{
+		{SignerEntry: SignerEntryItem{Account: &master, SignerWeight: &one}},
+	}}
+	root := &AccountRoot{Account: &payment.Account}
+	if authority, err := CheckAuthority(payment, root, list); err != nil || authority != SignerListAuthority {
+		t.Fatalf("Expected SignerList authority got: %s %v", authority, err)
+	}
+	signerRoot := &AccountRoot{Account: &master, Flags: &disabled}
+	if _, err := CheckAuthority(payment, root, list, signerRoot); err == nil {
+		t.Fatal("Signer with disabled master key was accepted")
+	}
+}
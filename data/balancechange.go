@@ -0,0 +1,467 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ChangeReason is the cause of a BalanceChange
+type ChangeReason uint8
+
+const (
+	ReasonFee             ChangeReason = iota // Transaction fee, destroyed
+	ReasonPayment                             // Sent or received by a Payment
+	ReasonTrade                               // Exchanged through an Offer or an AMM pool
+	ReasonRipple                              // Passed through an issuer or rippling account
+	ReasonLPToken                             // LP tokens issued, redeemed or bid by an AMM
+	ReasonAMM                                 // Deposited in or withdrawn from an AMM pool
+	ReasonEscrowLock                          // Locked in an Escrow by EscrowCreate
+	ReasonEscrowRelease                       // Released from an Escrow by EscrowFinish
+	ReasonEscrowReturn                        // Returned from an Escrow by EscrowCancel
+	ReasonChannelLock                         // Locked in a PayChannel by its creation or funding
+	ReasonChannelClaim                        // Claimed from a PayChannel
+	ReasonChannelReturn                       // Returned from a PayChannel when it closed
+	ReasonCheckCash                           // Paid by cashing a Check
+	ReasonAccountDelete                       // Sent by deleting an account
+	ReasonClawback                            // Clawed back by the issuer
+	ReasonNFTokenSale                         // Paid for an NFToken or in fees for its sale
+	ReasonNFTokenMint                         // NFToken minted
+	ReasonNFTokenBurn                         // NFToken burned
+	ReasonNFTokenTransfer                     // NFToken bought or sold
+	ReasonTransfer                            // Any other change
+)
+
+var changeReasons = [...]string{
+	ReasonFee:             "Fee",
+	ReasonPayment:         "Payment",
+	ReasonTrade:           "Trade",
+	ReasonRipple:          "Ripple",
+	ReasonLPToken:         "LPToken",
+	ReasonAMM:             "AMM",
+	ReasonEscrowLock:      "EscrowLock",
+	ReasonEscrowRelease:   "EscrowRelease",
+	ReasonEscrowReturn:    "EscrowReturn",
+	ReasonChannelLock:     "ChannelLock",
+	ReasonChannelClaim:    "ChannelClaim",
+	ReasonChannelReturn:   "ChannelReturn",
+	ReasonCheckCash:       "CheckCash",
+	ReasonAccountDelete:   "AccountDelete",
+	ReasonClawback:        "Clawback",
+	ReasonNFTokenSale:     "NFTokenSale",
+	ReasonNFTokenMint:     "NFTokenMint",
+	ReasonNFTokenBurn:     "NFTokenBurn",
+	ReasonNFTokenTransfer: "NFTokenTransfer",
+	ReasonTransfer:        "Transfer",
+}
+
+func (r ChangeReason) String() string {
+	if int(r) < len(changeReasons) {
+		return changeReasons[r]
+	}
+	return fmt.Sprintf("Unknown(%d)", r)
+}
+
+// BalanceChange is a change to an account's XRP balance, its side of a
+// trust line or its holding of an NFToken. The changes of an account's
+// balance with a counterparty sum to the total change of that balance.
+type BalanceChange struct {
+	Account      Account
+	Counterparty Account  // Zero for XRP and NFTokens
+	Currency     Currency // Zero for XRP and NFTokens
+	NFTokenID    *Hash256 // Only for NFTokens, with a Change of 1 or -1
+	Change       Value
+	Balance      *Value // The balance after the transaction, nil for NFTokens
+	Reason       ChangeReason
+}
+
+func (c BalanceChange) String() string {
+	asset := c.Currency.String()
+	if c.NFTokenID != nil {
+		asset = c.NFTokenID.String()
+	} else if !c.Currency.IsNative() {
+		asset += "/" + c.Counterparty.String()
+	}
+	return fmt.Sprintf("%-34s %-15s %20s %s", c.Account, c.Reason, c.Change, asset)
+}
+
+type BalanceChangeSlice []BalanceChange
+
+func (s BalanceChangeSlice) Len() int      { return len(s) }
+func (s BalanceChangeSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s BalanceChangeSlice) Less(i, j int) bool {
+	a, b := s[i], s[j]
+	switch {
+	case a.Account != b.Account:
+		return a.Account.Less(b.Account)
+	case (a.NFTokenID == nil) != (b.NFTokenID == nil):
+		return a.NFTokenID == nil
+	case a.NFTokenID != nil && *a.NFTokenID != *b.NFTokenID:
+		return a.NFTokenID.Compare(*b.NFTokenID) < 0
+	case a.Currency != b.Currency:
+		return a.Currency.Less(b.Currency)
+	case a.Counterparty != b.Counterparty:
+		return a.Counterparty.Less(b.Counterparty)
+	default:
+		return a.Reason < b.Reason
+	}
+}
+
+// Filter returns the changes of account
+func (s BalanceChangeSlice) Filter(account Account) BalanceChangeSlice {
+	var filtered BalanceChangeSlice
+	for _, c := range s {
+		if c.Account == account {
+			filtered = append(filtered, c)
+		}
+	}
+	return filtered
+}
+
+type changeKey struct {
+	account, counterparty Account
+	currency              Currency
+}
+
+// changeBuilder first records the whole change of each balance, and then
+// moves the parts which have a known reason, such as the fee or the funds
+// locked in an Escrow, into separate changes. What remains of each balance
+// is explained by the transaction type and the role of the account.
+type changeBuilder struct {
+	txm      *TransactionWithMetaData
+	keys     []changeKey
+	balances map[changeKey]*BalanceChange
+	changes  BalanceChangeSlice
+	traders  map[Account]bool
+	amms     map[Account]bool
+	writer   *Account // of a cashed Check
+}
+
+// BalanceChanges returns every change which the transaction made to the
+// balances of XRP, tokens and NFTokens, each with its reason. The fee is
+// separate from any other change to the sender's XRP balance.
+func (txm *TransactionWithMetaData) BalanceChanges() (BalanceChangeSlice, error) {
+	b := &changeBuilder{
+		txm:      txm,
+		balances: make(map[changeKey]*BalanceChange),
+		traders:  make(map[Account]bool),
+		amms:     make(map[Account]bool),
+	}
+	if err := b.collect(); err != nil {
+		return nil, err
+	}
+	if err := b.fee(); err != nil {
+		return nil, err
+	}
+	if err := b.locked(); err != nil {
+		return nil, err
+	}
+	if err := b.nftokens(); err != nil {
+		return nil, err
+	}
+	for _, key := range b.keys {
+		c := b.balances[key]
+		if c.Change.IsZero() {
+			continue
+		}
+		c.Reason = b.reason(key)
+		b.changes = append(b.changes, *c)
+	}
+	sort.Sort(b.changes)
+	return b.changes, nil
+}
+
+func (b *changeBuilder) add(key changeKey, change, balance *Value) {
+	if _, ok := b.balances[key]; !ok {
+		b.keys = append(b.keys, key)
+	}
+	b.balances[key] = &BalanceChange{
+		Account:      key.account,
+		Counterparty: key.counterparty,
+		Currency:     key.currency,
+		Change:       *change,
+		Balance:      balance,
+	}
+}
+
+// collect records the whole change of each XRP balance and each side of
+// each trust line
+func (b *changeBuilder) collect() error {
+	for i := range b.txm.MetaData.AffectedNodes {
+		_, final, previous, state := b.txm.MetaData.AffectedNodes[i].AffectedNode()
+		switch f := final.(type) {
+		case *AccountRoot:
+			if f.AMMID != nil && f.Account != nil {
+				b.amms[*f.Account] = true
+			}
+			before := previous.(*AccountRoot).Balance
+			if state == Created {
+				before = &zeroNative
+			}
+			if before == nil || f.Balance == nil || f.Account == nil {
+				continue
+			}
+			change, err := f.Balance.Subtract(*before)
+			if err != nil {
+				return err
+			}
+			b.add(changeKey{*f.Account, zeroAccount, zeroCurrency}, change, f.Balance)
+		case *RippleState:
+			if amm := ammHolder(f, previous.(*RippleState)); amm != nil {
+				b.amms[*amm] = true
+			}
+			if f.Balance == nil || f.LowLimit == nil || f.HighLimit == nil {
+				continue
+			}
+			var before *Value
+			if p := previous.(*RippleState).Balance; p != nil {
+				before = p.Value
+			}
+			if state == Created {
+				before = f.Balance.Value.ZeroClone()
+			}
+			if before == nil {
+				continue
+			}
+			change, err := f.Balance.Value.Subtract(*before)
+			if err != nil {
+				return err
+			}
+			low, high, currency := f.LowLimit.Issuer, f.HighLimit.Issuer, f.Balance.Currency
+			b.add(changeKey{low, high, currency}, change, f.Balance.Value)
+			b.add(changeKey{high, low, currency}, change.Negate(), f.Balance.Value.Negate())
+		case *Offer:
+			if state != Created && f.Account != nil {
+				b.traders[*f.Account] = true
+			}
+		case *Check:
+			if state == Deleted && b.txm.GetTransactionType() == CHECK_CASH {
+				b.writer = f.Account
+			}
+		}
+	}
+	return nil
+}
+
+// separate moves amount out of account's balance of its asset into a
+// separate change with reason
+func (b *changeBuilder) separate(account Account, amount *Amount, reason ChangeReason) error {
+	if amount.IsZero() {
+		return nil
+	}
+	key := changeKey{account, zeroAccount, zeroCurrency}
+	if !amount.IsNative() {
+		key = changeKey{account, amount.Issuer, amount.Currency}
+	}
+	change := BalanceChange{
+		Account:      key.account,
+		Counterparty: key.counterparty,
+		Currency:     key.currency,
+		Change:       *amount.Value,
+		Reason:       reason,
+	}
+	if remaining, ok := b.balances[key]; ok {
+		rest, err := remaining.Change.Subtract(*amount.Value)
+		if err != nil {
+			return err
+		}
+		remaining.Change, change.Balance = *rest, remaining.Balance
+	}
+	b.changes = append(b.changes, change)
+	return nil
+}
+
+func (b *changeBuilder) fee() error {
+	base := b.txm.GetBase()
+	fee := newAmount(base.Fee.Negate(), zeroCurrency, zeroAccount)
+	return b.separate(base.Account, fee, ReasonFee)
+}
+
+// locked separates the funds locked in and released from Escrows and
+// PayChannels
+func (b *changeBuilder) locked() error {
+	for i := range b.txm.MetaData.AffectedNodes {
+		_, final, previous, state := b.txm.MetaData.AffectedNodes[i].AffectedNode()
+		var err error
+		switch f := final.(type) {
+		case *Escrow:
+			switch {
+			case f.Amount.Value == nil:
+			case state == Created:
+				err = b.separate(f.Account, f.Amount.Negate(), ReasonEscrowLock)
+			case state == Deleted && b.txm.GetTransactionType() == ESCROW_FINISH:
+				err = b.separate(f.Destination, &f.Amount, ReasonEscrowRelease)
+			case state == Deleted:
+				err = b.separate(f.Account, &f.Amount, ReasonEscrowReturn)
+			}
+		case *PayChannel:
+			err = b.channel(f, previous.(*PayChannel), state)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *changeBuilder) channel(f, p *PayChannel, state LedgerEntryState) error {
+	if f.Account == nil || f.Destination == nil || f.Amount == nil {
+		return nil
+	}
+	if state == Created {
+		return b.separate(*f.Account, f.Amount.Negate(), ReasonChannelLock)
+	}
+	balance := f.Balance
+	if balance == nil {
+		balance = f.Amount.ZeroClone()
+	}
+	if p.Amount != nil {
+		funded, err := f.Amount.Subtract(p.Amount)
+		if err != nil {
+			return err
+		}
+		if err := b.separate(*f.Account, funded.Negate(), ReasonChannelLock); err != nil {
+			return err
+		}
+	}
+	if p.Balance != nil {
+		claimed, err := balance.Subtract(p.Balance)
+		if err != nil {
+			return err
+		}
+		if err := b.separate(*f.Destination, claimed, ReasonChannelClaim); err != nil {
+			return err
+		}
+	}
+	if state != Deleted {
+		return nil
+	}
+	returned, err := f.Amount.Subtract(balance)
+	if err != nil {
+		return err
+	}
+	return b.separate(*f.Account, returned, ReasonChannelReturn)
+}
+
+// nftokens adds the NFTokens which each owner gained or lost. An NFToken
+// which moves between the pages of the same owner is not a change.
+func (b *changeBuilder) nftokens() error {
+	var (
+		owners []Account
+		held   = make(map[Account]map[Hash256]int)
+	)
+	count := func(owner Account, tokens []NFToken, n int) {
+		if _, ok := held[owner]; !ok {
+			owners = append(owners, owner)
+			held[owner] = make(map[Hash256]int)
+		}
+		for _, token := range tokens {
+			if token.NFTokenID != nil {
+				held[owner][*token.NFTokenID] += n
+			}
+		}
+	}
+	for i := range b.txm.MetaData.AffectedNodes {
+		node, final, previous, state := b.txm.MetaData.AffectedNodes[i].AffectedNode()
+		f, ok := final.(*NFTokenPage)
+		if !ok || node.LedgerIndex == nil {
+			continue
+		}
+		// The owner is the first 20 bytes of the index of each page
+		var owner Account
+		copy(owner[:], node.LedgerIndex[:len(owner)])
+		before := previous.(*NFTokenPage).NFTokens
+		if before == nil && state != Created {
+			before = f.NFTokens
+		}
+		count(owner, before, -1)
+		if state != Deleted {
+			count(owner, f.NFTokens, 1)
+		}
+	}
+	reason := ReasonNFTokenTransfer
+	switch b.txm.GetTransactionType() {
+	case NFTOKEN_MINT:
+		reason = ReasonNFTokenMint
+	case NFTOKEN_BURN:
+		reason = ReasonNFTokenBurn
+	}
+	one, err := NewNonNativeValue(1, 0)
+	if err != nil {
+		return err
+	}
+	for _, owner := range owners {
+		for id, n := range held[owner] {
+			if n == 0 {
+				continue
+			}
+			change := one
+			if n < 0 {
+				change = one.Negate()
+			}
+			id := id
+			b.changes = append(b.changes, BalanceChange{
+				Account:   owner,
+				NFTokenID: &id,
+				Change:    *change,
+				Reason:    reason,
+			})
+		}
+	}
+	return nil
+}
+
+// isLPToken returns whether currency is the LP token of an AMM, the code
+// of which starts with 0x03
+func isLPToken(currency Currency) bool {
+	return currency[0] == 0x03
+}
+
+// reason returns the reason for the remaining change of a balance
+func (b *changeBuilder) reason(key changeKey) ChangeReason {
+	var (
+		base        = b.txm.GetBase()
+		party       = key.account == base.Account
+		liquidity   = b.traders[key.account] || b.amms[key.account]
+		destination Account
+	)
+	switch tx := b.txm.Transaction.(type) {
+	case *Payment:
+		destination = tx.Destination
+	case *AccountDelete:
+		destination = tx.Destination
+	}
+	party = party || key.account == destination
+	if isLPToken(key.currency) {
+		return ReasonLPToken
+	}
+	switch base.TransactionType {
+	case PAYMENT:
+		if party {
+			return ReasonPayment
+		}
+	case CHECK_CASH:
+		if party || (b.writer != nil && key.account == *b.writer) {
+			return ReasonCheckCash
+		}
+	case OFFER_CREATE:
+		if party {
+			return ReasonTrade
+		}
+	case ACCOUNT_DELETE:
+		return ReasonAccountDelete
+	case CLAWBACK:
+		return ReasonClawback
+	case NFTOKEN_ACCEPT_OFFER:
+		return ReasonNFTokenSale
+	case AMM_CREATE, AMM_DEPOSIT, AMM_WITHDRAW, AMM_DELETE:
+		return ReasonAMM
+	}
+	switch base.TransactionType {
+	case PAYMENT, CHECK_CASH, OFFER_CREATE:
+		if liquidity {
+			return ReasonTrade
+		}
+		return ReasonRipple
+	}
+	return ReasonTransfer
+}
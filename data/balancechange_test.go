@@ -0,0 +1,142 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const (
+	changeOwner       = "rGgj3GurcrAqgBXGVoS9wvQG3Hjkj5oCbj"
+	changeDestination = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+)
+
+func summarise(t *testing.T, txm *TransactionWithMetaData) []string {
+	t.Helper()
+	changes, err := txm.BalanceChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var summary []string
+	for _, c := range changes {
+		summary = append(summary, fmt.Sprintf("%s %s %s", c.Account, c.Reason, c.Change))
+	}
+	return summary
+}
+
+func checkChanges(t *testing.T, name string, txm *TransactionWithMetaData, want ...string) {
+	t.Helper()
+	got := summarise(t, txm)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%s: Expected:\n%s\nGot:\n%s", name, strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+func parseTransaction(t *testing.T, s string) *TransactionWithMetaData {
+	t.Helper()
+	s = strings.NewReplacer("OWNER", changeOwner, "DESTINATION", changeDestination).Replace(s)
+	var txm TransactionWithMetaData
+	if err := json.Unmarshal([]byte(s), &txm); err != nil {
+		t.Fatal(err)
+	}
+	return &txm
+}
+
+const escrowCreate = `{
+	"TransactionType": "EscrowCreate", "Account": "OWNER", "Destination": "DESTINATION",
+	"Amount": "1000000", "Fee": "12", "Sequence": 1, "hash": "1111111111111111111111111111111111111111111111111111111111111111",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000001",
+			"FinalFields": {"Account": "OWNER", "Balance": "8999988", "Flags": 0, "OwnerCount": 1, "Sequence": 2},
+			"PreviousFields": {"Balance": "10000000", "OwnerCount": 0, "Sequence": 1}}},
+		{"CreatedNode": {"LedgerEntryType": "Escrow", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000002",
+			"NewFields": {"Account": "OWNER", "Destination": "DESTINATION", "Amount": "1000000"}}}
+	]}
+}`
+
+const channelClose = `{
+	"TransactionType": "PaymentChannelClaim", "Account": "OWNER", "Flags": 131072,
+	"Channel": "0000000000000000000000000000000000000000000000000000000000000003",
+	"Balance": "300000", "Fee": "12", "Sequence": 2, "hash": "2222222222222222222222222222222222222222222222222222222222222222",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000001",
+			"FinalFields": {"Account": "OWNER", "Balance": "9699988", "Flags": 0, "OwnerCount": 0, "Sequence": 3},
+			"PreviousFields": {"Balance": "9000000", "OwnerCount": 1, "Sequence": 2}}},
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000004",
+			"FinalFields": {"Account": "DESTINATION", "Balance": "1100000", "Flags": 0, "OwnerCount": 0, "Sequence": 1},
+			"PreviousFields": {"Balance": "1000000"}}},
+		{"DeletedNode": {"LedgerEntryType": "PayChannel", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000003",
+			"FinalFields": {"Account": "OWNER", "Destination": "DESTINATION", "Amount": "1000000", "Balance": "300000", "SettleDelay": 60},
+			"PreviousFields": {"Balance": "200000"}}}
+	]}
+}`
+
+const nftokenMint = `{
+	"TransactionType": "NFTokenMint", "Account": "OWNER", "NFTokenTaxon": 0,
+	"Fee": "10", "Sequence": 3, "hash": "3333333333333333333333333333333333333333333333333333333333333333",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000001",
+			"FinalFields": {"Account": "OWNER", "Balance": "9699978", "Flags": 0, "OwnerCount": 1, "Sequence": 4, "MintedNFTokens": 1},
+			"PreviousFields": {"Balance": "9699988", "OwnerCount": 0, "Sequence": 3}}},
+		{"CreatedNode": {"LedgerEntryType": "NFTokenPage", "LedgerIndex": "PAGE",
+			"NewFields": {"NFTokens": [{"NFTokenID": "000800004E5A1D5E2D3AB5DF6A7D8D5E4B2F0B2A3C6D8E9F0000099A00000000"}]}}}
+	]}
+}`
+
+func TestBalanceChanges(t *testing.T) {
+	checkChanges(t, "Payment", readLedger(t, "testdata/ledger_6000000.json").Transactions[0],
+		"r3kmLJN5D28dHuH8vZNUZpMC43pEHpaocV Fee -0.00001",
+		"r3kmLJN5D28dHuH8vZNUZpMC43pEHpaocV Payment -10000",
+		"rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj Payment 10000",
+	)
+
+	rippling := readPayment(t, "testdata/transaction_payment_with_rippling.json")
+	changes, err := rippling.BalanceChanges()
+	if err != nil {
+		t.Fatal(err)
+	}
+	destination, err := NewAccountFromAddress(changeDestination)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := zeroNonNative
+	for _, c := range changes.Filter(*destination) {
+		if c.Reason != ReasonPayment {
+			t.Errorf("Expected Payment got %s", c.Reason)
+		}
+		sum, err := received.Add(c.Change)
+		if err != nil {
+			t.Fatal(err)
+		}
+		received = *sum
+	}
+	if received.String() != "20" {
+		t.Errorf("Expected destination to receive 20 got %s", received)
+	}
+	for _, c := range changes {
+		if c.Reason == ReasonRipple && (c.Account.String() == changeOwner || c.Account == *destination) {
+			t.Errorf("Expected no rippling for %s", c.Account)
+		}
+	}
+
+	checkChanges(t, "EscrowCreate", parseTransaction(t, escrowCreate),
+		changeOwner+" Fee -0.000012",
+		changeOwner+" EscrowLock -1",
+	)
+	checkChanges(t, "PaymentChannelClaim", parseTransaction(t, channelClose),
+		changeDestination+" ChannelClaim 0.1",
+		changeOwner+" Fee -0.000012",
+		changeOwner+" ChannelReturn 0.7",
+	)
+
+	owner, err := NewAccountFromAddress(changeOwner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	mint := strings.Replace(nftokenMint, "PAGE", fmt.Sprintf("%X%024X", owner[:], 0), 1)
+	checkChanges(t, "NFTokenMint", parseTransaction(t, mint),
+		changeOwner+" Fee -0.00001",
+		changeOwner+" NFTokenMint 1",
+	)
+}
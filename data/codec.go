@@ -0,0 +1,156 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+//go:generate go run ../tools/codecgen -o codec_generated.go
+
+// useGeneratedCodec selects the code generated encoders and decoders in
+// codec_generated.go for the types which have them. The reflective codec in
+// encoder.go and decoder.go handles everything else and remains the
+// reference implementation which the generated code must match byte for byte.
+var useGeneratedCodec = true
+
+// generatedCodec is implemented by the types in codec_generated.go. inner is
+// true when the value is encoded within another object, which suppresses
+// the LedgerEntryType of ledger entries held in metadata.
+type generatedCodec interface {
+	marshal(w io.Writer, ignoreSigningFields, inner bool) error
+	unmarshal(r Reader, s *decodeState) error
+}
+
+func generated(value interface{}) (generatedCodec, bool) {
+	if !useGeneratedCodec {
+		return nil, false
+	}
+	codec, ok := value.(generatedCodec)
+	return codec, ok
+}
+
+// encodeReflect encodes value using the reflective encoder. Generated
+// encoders fall back to it for values carrying UnknownFields.
+func encodeReflect(w io.Writer, value interface{}, ignoreSigningFields, inner bool) error {
+	depth := 0
+	if inner {
+		depth = 1
+	}
+	return encodeFields(w, value, depth, ignoreSigningFields)
+}
+
+// encodeInner encodes the fields of a ledger entry held within metadata
+func encodeInner(w io.Writer, le LedgerEntry, ignoreSigningFields bool) error {
+	if codec, ok := generated(le); ok {
+		return codec.marshal(w, ignoreSigningFields, true)
+	}
+	return encodeReflect(w, le, ignoreSigningFields, true)
+}
+
+// decodeObject decodes fields into the struct pointed to by value until the
+// end of the object or the input.
+func decodeObject(r Reader, value interface{}, s *decodeState) error {
+	if codec, ok := generated(value); ok {
+		return codec.unmarshal(r, s)
+	}
+	v := reflect.ValueOf(value)
+	return readObject(r, &v, s)
+}
+
+func newLedgerEntry(leType LedgerEntryType) (LedgerEntry, error) {
+	if int(leType) >= len(LedgerEntryFactory) || LedgerEntryFactory[leType] == nil {
+		return nil, fmt.Errorf("Unknown LedgerEntryType: %d", leType)
+	}
+	return LedgerEntryFactory[leType](), nil
+}
+
+// fieldError wraps err for the field e starting at offset
+func (s *decodeState) fieldError(offset int, e enc, err error) error {
+	s.push(encodings[e])
+	defer s.pop()
+	return s.wrap(offset, &e, err)
+}
+
+var indexNames [64]string
+
+func init() {
+	for i := range indexNames {
+		indexNames[i] = fmt.Sprintf("[%d]", i)
+	}
+}
+
+func (s *decodeState) pushIndex(i int) {
+	if i < len(indexNames) {
+		s.push(indexNames[i])
+	} else {
+		s.push(fmt.Sprintf("[%d]", i))
+	}
+}
+
+// header returns the encoded field header for e
+func (e enc) header() []byte {
+	var b bytes.Buffer
+	writeEncoding(&b, e)
+	return b.Bytes()
+}
+
+func readUint8(r Reader) (uint8, error) {
+	return r.ReadByte()
+}
+
+func readUint16(r Reader) (uint16, error) {
+	v, err := readUint(r, 2)
+	return uint16(v), err
+}
+
+func readUint32(r Reader) (uint32, error) {
+	v, err := readUint(r, 4)
+	return uint32(v), err
+}
+
+func readUint64(r Reader) (uint64, error) {
+	return readUint(r, 8)
+}
+
+func readUint(r Reader, n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		b, err := r.ReadByte()
+		if err != nil {
+			if i > 0 && err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return 0, err
+		}
+		v = v<<8 | uint64(b)
+	}
+	return v, nil
+}
+
+func writeUint8(w io.Writer, v uint8) error {
+	return writeUint(w, uint64(v), 1)
+}
+
+func writeUint16(w io.Writer, v uint16) error {
+	return writeUint(w, uint64(v), 2)
+}
+
+func writeUint32(w io.Writer, v uint32) error {
+	return writeUint(w, uint64(v), 4)
+}
+
+func writeUint64(w io.Writer, v uint64) error {
+	return writeUint(w, v, 8)
+}
+
+func writeUint(w io.Writer, v uint64, n int) error {
+	var b [8]byte
+	for i := n - 1; i >= 0; i-- {
+		b[i] = uint8(v)
+		v >>= 8
+	}
+	_, err := w.Write(b[:n])
+	return err
+}
@@ -0,0 +1,12418 @@
+// Code generated by codecgen. DO NOT EDIT.
+
+package data
+
+import (
+	"fmt"
+	"io"
+)
+
+// Types using the reflective codec: EscrowFinish, AMMDeposit, AMMWithdraw, AMMVote, AMMBid, AMMDelete, OracleSet, OracleDelete
+
+var (
+	hdrAMMID                 = enc{5, 14}.header()
+	hdrAccount               = enc{8, 1}.header()
+	hdrAccountTxnID          = enc{5, 9}.header()
+	hdrAffectedNodes         = enc{15, 8}.header()
+	hdrAmendment             = enc{5, 19}.header()
+	hdrAmendments            = enc{19, 3}.header()
+	hdrAmount                = enc{6, 1}.header()
+	hdrAmount2               = enc{6, 11}.header()
+	hdrAsset                 = enc{24, 3}.header()
+	hdrAsset2                = enc{24, 4}.header()
+	hdrAuctionSlot           = enc{14, 26}.header()
+	hdrAuthAccount           = enc{14, 27}.header()
+	hdrAuthAccounts          = enc{15, 25}.header()
+	hdrAuthorize             = enc{8, 5}.header()
+	hdrBalance               = enc{6, 2}.header()
+	hdrBaseFee               = enc{3, 5}.header()
+	hdrBaseFeeDrops          = enc{6, 22}.header()
+	hdrBookDirectory         = enc{5, 16}.header()
+	hdrBookNode              = enc{3, 3}.header()
+	hdrBurnedNFTokens        = enc{2, 44}.header()
+	hdrCancelAfter           = enc{2, 36}.header()
+	hdrChannel               = enc{5, 22}.header()
+	hdrCheckID               = enc{5, 24}.header()
+	hdrClearFlag             = enc{2, 34}.header()
+	hdrCloseTime             = enc{2, 7}.header()
+	hdrCondition             = enc{7, 17}.header()
+	hdrCreatedNode           = enc{14, 3}.header()
+	hdrDeletedNode           = enc{14, 4}.header()
+	hdrDeliverMin            = enc{6, 10}.header()
+	hdrDeliveredAmount       = enc{6, 18}.header()
+	hdrDestination           = enc{8, 3}.header()
+	hdrDestinationNode       = enc{3, 9}.header()
+	hdrDestinationTag        = enc{2, 14}.header()
+	hdrDigest                = enc{5, 21}.header()
+	hdrDisabledValidator     = enc{14, 19}.header()
+	hdrDisabledValidators    = enc{15, 17}.header()
+	hdrDiscountedFee         = enc{1, 6}.header()
+	hdrDomain                = enc{7, 7}.header()
+	hdrEmailHash             = enc{4, 1}.header()
+	hdrEndOfArray            = enc{15, 1}.header()
+	hdrEndOfObject           = enc{14, 1}.header()
+	hdrExchangeRate          = enc{3, 6}.header()
+	hdrExpiration            = enc{2, 10}.header()
+	hdrFee                   = enc{6, 8}.header()
+	hdrFinalFields           = enc{14, 7}.header()
+	hdrFinishAfter           = enc{2, 37}.header()
+	hdrFirstLedgerSequence   = enc{2, 26}.header()
+	hdrFirstNFTokenSequence  = enc{2, 50}.header()
+	hdrFlags                 = enc{2, 2}.header()
+	hdrHashes                = enc{19, 2}.header()
+	hdrHighLimit             = enc{6, 7}.header()
+	hdrHighNode              = enc{3, 8}.header()
+	hdrHighQualityIn         = enc{2, 16}.header()
+	hdrHighQualityOut        = enc{2, 17}.header()
+	hdrIndexNext             = enc{3, 1}.header()
+	hdrIndexPrevious         = enc{3, 2}.header()
+	hdrIndexes               = enc{19, 1}.header()
+	hdrInvoiceID             = enc{5, 17}.header()
+	hdrIssuer                = enc{8, 4}.header()
+	hdrLPTokenBalance        = enc{6, 31}.header()
+	hdrLastLedgerSequence    = enc{2, 27}.header()
+	hdrLedgerEntryType       = enc{1, 1}.header()
+	hdrLedgerIndex           = enc{5, 6}.header()
+	hdrLimitAmount           = enc{6, 3}.header()
+	hdrLowLimit              = enc{6, 6}.header()
+	hdrLowNode               = enc{3, 7}.header()
+	hdrLowQualityIn          = enc{2, 18}.header()
+	hdrLowQualityOut         = enc{2, 19}.header()
+	hdrMajorities            = enc{15, 16}.header()
+	hdrMajority              = enc{14, 18}.header()
+	hdrMemo                  = enc{14, 10}.header()
+	hdrMemoData              = enc{7, 13}.header()
+	hdrMemoFormat            = enc{7, 14}.header()
+	hdrMemoType              = enc{7, 12}.header()
+	hdrMemos                 = enc{15, 9}.header()
+	hdrMessageKey            = enc{7, 2}.header()
+	hdrMintedNFTokens        = enc{2, 43}.header()
+	hdrModifiedNode          = enc{14, 5}.header()
+	hdrNFToken               = enc{14, 12}.header()
+	hdrNFTokenBrokerFee      = enc{6, 19}.header()
+	hdrNFTokenBuyOffer       = enc{5, 28}.header()
+	hdrNFTokenID             = enc{5, 10}.header()
+	hdrNFTokenMinter         = enc{8, 9}.header()
+	hdrNFTokenOfferNode      = enc{3, 12}.header()
+	hdrNFTokenOffers         = enc{19, 4}.header()
+	hdrNFTokenSellOffer      = enc{5, 29}.header()
+	hdrNFTokenTaxon          = enc{2, 42}.header()
+	hdrNFTokens              = enc{15, 10}.header()
+	hdrNewFields             = enc{14, 8}.header()
+	hdrNextPageMin           = enc{5, 27}.header()
+	hdrOfferSequence         = enc{2, 25}.header()
+	hdrOwner                 = enc{8, 2}.header()
+	hdrOwnerCount            = enc{2, 13}.header()
+	hdrOwnerNode             = enc{3, 4}.header()
+	hdrPaths                 = enc{18, 1}.header()
+	hdrPreviousFields        = enc{14, 6}.header()
+	hdrPreviousPageMin       = enc{5, 26}.header()
+	hdrPreviousTxnID         = enc{5, 5}.header()
+	hdrPreviousTxnLgrSeq     = enc{2, 5}.header()
+	hdrPrice                 = enc{6, 28}.header()
+	hdrPublicKey             = enc{7, 1}.header()
+	hdrQualityIn             = enc{2, 20}.header()
+	hdrQualityOut            = enc{2, 21}.header()
+	hdrReferenceFeeUnits     = enc{2, 30}.header()
+	hdrRegularKey            = enc{8, 8}.header()
+	hdrReserveBase           = enc{2, 31}.header()
+	hdrReserveBaseDrops      = enc{6, 23}.header()
+	hdrReserveIncrement      = enc{2, 32}.header()
+	hdrReserveIncrementDrops = enc{6, 24}.header()
+	hdrRootIndex             = enc{5, 8}.header()
+	hdrSendMax               = enc{6, 9}.header()
+	hdrSequence              = enc{2, 4}.header()
+	hdrSetFlag               = enc{2, 33}.header()
+	hdrSettleDelay           = enc{2, 39}.header()
+	hdrSignature             = enc{7, 6}.header()
+	hdrSigner                = enc{14, 16}.header()
+	hdrSignerEntries         = enc{15, 4}.header()
+	hdrSignerEntry           = enc{14, 11}.header()
+	hdrSignerListID          = enc{2, 38}.header()
+	hdrSignerQuorum          = enc{2, 35}.header()
+	hdrSignerWeight          = enc{1, 3}.header()
+	hdrSigners               = enc{15, 3}.header()
+	hdrSigningPubKey         = enc{7, 3}.header()
+	hdrSourceTag             = enc{2, 3}.header()
+	hdrTakerGets             = enc{6, 5}.header()
+	hdrTakerGetsCurrency     = enc{17, 3}.header()
+	hdrTakerGetsIssuer       = enc{17, 4}.header()
+	hdrTakerPays             = enc{6, 4}.header()
+	hdrTakerPaysCurrency     = enc{17, 1}.header()
+	hdrTakerPaysIssuer       = enc{17, 2}.header()
+	hdrTickSize              = enc{16, 16}.header()
+	hdrTicketCount           = enc{2, 40}.header()
+	hdrTicketSequence        = enc{2, 41}.header()
+	hdrTradingFee            = enc{1, 5}.header()
+	hdrTransactionIndex      = enc{2, 28}.header()
+	hdrTransactionResult     = enc{16, 3}.header()
+	hdrTransactionType       = enc{1, 2}.header()
+	hdrTransferFee           = enc{1, 4}.header()
+	hdrTransferRate          = enc{2, 11}.header()
+	hdrTxnSignature          = enc{7, 4}.header()
+	hdrUNLModifyDisabling    = enc{16, 17}.header()
+	hdrUNLModifyValidator    = enc{7, 19}.header()
+	hdrURI                   = enc{7, 5}.header()
+	hdrUnauthorize           = enc{8, 6}.header()
+	hdrValidatorToDisable    = enc{7, 20}.header()
+	hdrValidatorToReEnable   = enc{7, 21}.header()
+	hdrVoteEntry             = enc{14, 25}.header()
+	hdrVoteSlots             = enc{15, 12}.header()
+	hdrVoteWeight            = enc{2, 48}.header()
+	hdrWalletLocator         = enc{5, 7}.header()
+	hdrWalletSize            = enc{2, 12}.header()
+)
+
+func (x *Payment) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.InvoiceID != nil {
+		if _, err := w.Write(hdrInvoiceID); err != nil {
+			return err
+		}
+		if err := x.InvoiceID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.SendMax != nil {
+		if _, err := w.Write(hdrSendMax); err != nil {
+			return err
+		}
+		if err := x.SendMax.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.DeliverMin != nil {
+		if _, err := w.Write(hdrDeliverMin); err != nil {
+			return err
+		}
+		if err := x.DeliverMin.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if x.Paths != nil && len(*x.Paths) > 0 {
+		if _, err := w.Write(hdrPaths); err != nil {
+			return err
+		}
+		if err := x.Paths.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Payment) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 17}:
+			x.InvoiceID = new(Hash256)
+			if err := x.InvoiceID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 9}:
+			x.SendMax = new(Amount)
+			if err := x.SendMax.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 10}:
+			x.DeliverMin = new(Amount)
+			if err := x.DeliverMin.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{18, 1}:
+			x.Paths = new(PathSet)
+			if err := x.Paths.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SignerItem) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxnSignature != nil && len(*x.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.Account.Marshal(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *SignerItem) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{7, 3}:
+			x.SigningPubKey = new(PublicKey)
+			if err := x.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxnSignature = new(VariableLength)
+			if err := x.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *MemoItem) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.MemoType) > 0 {
+		if _, err := w.Write(hdrMemoType); err != nil {
+			return err
+		}
+		if err := x.MemoType.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.MemoData) > 0 {
+		if _, err := w.Write(hdrMemoData); err != nil {
+			return err
+		}
+		if err := x.MemoData.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.MemoFormat) > 0 {
+		if _, err := w.Write(hdrMemoFormat); err != nil {
+			return err
+		}
+		if err := x.MemoFormat.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *MemoItem) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{7, 12}:
+			if err := x.MemoType.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 13}:
+			if err := x.MemoData.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 14}:
+			if err := x.MemoFormat.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *EscrowCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.CancelAfter != nil {
+		if _, err := w.Write(hdrCancelAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.CancelAfter)); err != nil {
+			return err
+		}
+	}
+	if x.FinishAfter != nil {
+		if _, err := w.Write(hdrFinishAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.FinishAfter)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Digest != nil {
+		if _, err := w.Write(hdrDigest); err != nil {
+			return err
+		}
+		if err := x.Digest.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Condition != nil && len(*x.Condition) > 0 {
+		if _, err := w.Write(hdrCondition); err != nil {
+			return err
+		}
+		if err := x.Condition.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *EscrowCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 36}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.CancelAfter = new(uint32)
+			*x.CancelAfter = uint32(v)
+		case enc{2, 37}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FinishAfter = new(uint32)
+			*x.FinishAfter = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 21}:
+			x.Digest = new(Hash256)
+			if err := x.Digest.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 17}:
+			x.Condition = new(VariableLength)
+			if err := x.Condition.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AccountSet) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TransferRate != nil {
+		if _, err := w.Write(hdrTransferRate); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TransferRate)); err != nil {
+			return err
+		}
+	}
+	if x.WalletSize != nil {
+		if _, err := w.Write(hdrWalletSize); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.WalletSize)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.SetFlag != nil {
+		if _, err := w.Write(hdrSetFlag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SetFlag)); err != nil {
+			return err
+		}
+	}
+	if x.ClearFlag != nil {
+		if _, err := w.Write(hdrClearFlag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.ClearFlag)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.EmailHash != nil {
+		if _, err := w.Write(hdrEmailHash); err != nil {
+			return err
+		}
+		if err := x.EmailHash.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.WalletLocator != nil {
+		if _, err := w.Write(hdrWalletLocator); err != nil {
+			return err
+		}
+		if err := x.WalletLocator.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.MessageKey != nil && len(*x.MessageKey) > 0 {
+		if _, err := w.Write(hdrMessageKey); err != nil {
+			return err
+		}
+		if err := x.MessageKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Domain != nil && len(*x.Domain) > 0 {
+		if _, err := w.Write(hdrDomain); err != nil {
+			return err
+		}
+		if err := x.Domain.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if x.TickSize != nil {
+		if _, err := w.Write(hdrTickSize); err != nil {
+			return err
+		}
+		if err := writeUint8(w, uint8(*x.TickSize)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AccountSet) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 11}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TransferRate = new(uint32)
+			*x.TransferRate = uint32(v)
+		case enc{2, 12}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.WalletSize = new(uint32)
+			*x.WalletSize = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 33}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SetFlag = new(uint32)
+			*x.SetFlag = uint32(v)
+		case enc{2, 34}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ClearFlag = new(uint32)
+			*x.ClearFlag = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{4, 1}:
+			x.EmailHash = new(Hash128)
+			if err := x.EmailHash.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 7}:
+			x.WalletLocator = new(Hash256)
+			if err := x.WalletLocator.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 2}:
+			x.MessageKey = new(VariableLength)
+			if err := x.MessageKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 7}:
+			x.Domain = new(VariableLength)
+			if err := x.Domain.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{16, 16}:
+			v, err := readUint8(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TickSize = new(uint8)
+			*x.TickSize = uint8(v)
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *EscrowCancel) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrOfferSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.OfferSequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrOwner); err != nil {
+		return err
+	}
+	if err := x.Owner.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *EscrowCancel) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 25}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OfferSequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 2}:
+			if err := x.Owner.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SetRegularKey) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if x.RegularKey != nil {
+		if _, err := w.Write(hdrRegularKey); err != nil {
+			return err
+		}
+		if err := x.RegularKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SetRegularKey) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 8}:
+			x.RegularKey = new(RegularKey)
+			if err := x.RegularKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *OfferCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.OfferSequence != nil {
+		if _, err := w.Write(hdrOfferSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.OfferSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrTakerPays); err != nil {
+		return err
+	}
+	if err := x.TakerPays.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrTakerGets); err != nil {
+		return err
+	}
+	if err := x.TakerGets.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *OfferCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 25}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OfferSequence = new(uint32)
+			*x.OfferSequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 4}:
+			if err := x.TakerPays.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 5}:
+			if err := x.TakerGets.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *OfferCancel) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrOfferSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.OfferSequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *OfferCancel) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 25}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OfferSequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *TicketCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketCount != nil {
+		if _, err := w.Write(hdrTicketCount); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketCount)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *TicketCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 40}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketCount = new(uint32)
+			*x.TicketCount = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SignerListSet) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSignerQuorum); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.SignerQuorum)); err != nil {
+		return err
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.SignerEntries) > 0 {
+		if _, err := w.Write(hdrSignerEntries); err != nil {
+			return err
+		}
+		for i := range x.SignerEntries {
+			if err := x.SignerEntries[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SignerListSet) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 35}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SignerQuorum = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 4}:
+			s.push("SignerEntries")
+			array, more, err := unmarshalSignerEntryArray(r, s)
+			s.pop()
+			x.SignerEntries = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SignerEntryItem) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.SignerWeight != nil {
+		if _, err := w.Write(hdrSignerWeight); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(*x.SignerWeight)); err != nil {
+			return err
+		}
+	}
+	if x.WalletLocator != nil {
+		if _, err := w.Write(hdrWalletLocator); err != nil {
+			return err
+		}
+		if err := x.WalletLocator.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SignerEntryItem) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 3}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SignerWeight = new(uint16)
+			*x.SignerWeight = uint16(v)
+		case enc{5, 7}:
+			x.WalletLocator = new(Hash256)
+			if err := x.WalletLocator.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *PaymentChannelCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if x.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.CancelAfter != nil {
+		if _, err := w.Write(hdrCancelAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.CancelAfter)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSettleDelay); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.SettleDelay)); err != nil {
+		return err
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrPublicKey); err != nil {
+		return err
+	}
+	if err := x.PublicKey.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *PaymentChannelCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SourceTag = new(uint32)
+			*x.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 36}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.CancelAfter = new(uint32)
+			*x.CancelAfter = uint32(v)
+		case enc{2, 39}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SettleDelay = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 1}:
+			if err := x.PublicKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *PaymentChannelFund) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrChannel); err != nil {
+		return err
+	}
+	if err := x.Channel.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *PaymentChannelFund) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 22}:
+			if err := x.Channel.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *PaymentChannelClaim) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrChannel); err != nil {
+		return err
+	}
+	if err := x.Channel.Marshal(w); err != nil {
+		return err
+	}
+	if x.Amount != nil {
+		if _, err := w.Write(hdrAmount); err != nil {
+			return err
+		}
+		if err := x.Amount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Balance != nil {
+		if _, err := w.Write(hdrBalance); err != nil {
+			return err
+		}
+		if err := x.Balance.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.PublicKey != nil {
+		if _, err := w.Write(hdrPublicKey); err != nil {
+			return err
+		}
+		if err := x.PublicKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.Signature != nil && len(*x.Signature) > 0 {
+		if _, err := w.Write(hdrSignature); err != nil {
+			return err
+		}
+		if err := x.Signature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *PaymentChannelClaim) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 22}:
+			if err := x.Channel.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			x.Amount = new(Amount)
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 2}:
+			x.Balance = new(Amount)
+			if err := x.Balance.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 1}:
+			x.PublicKey = new(PublicKey)
+			if err := x.PublicKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 6}:
+			x.Signature = new(VariableLength)
+			if err := x.Signature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *CheckCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.InvoiceID != nil {
+		if _, err := w.Write(hdrInvoiceID); err != nil {
+			return err
+		}
+		if err := x.InvoiceID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrSendMax); err != nil {
+		return err
+	}
+	if err := x.SendMax.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *CheckCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 17}:
+			x.InvoiceID = new(Hash256)
+			if err := x.InvoiceID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 9}:
+			if err := x.SendMax.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *CheckCash) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrCheckID); err != nil {
+		return err
+	}
+	if err := x.CheckID.Marshal(w); err != nil {
+		return err
+	}
+	if x.Amount != nil {
+		if _, err := w.Write(hdrAmount); err != nil {
+			return err
+		}
+		if err := x.Amount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.DeliverMin != nil {
+		if _, err := w.Write(hdrDeliverMin); err != nil {
+			return err
+		}
+		if err := x.DeliverMin.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *CheckCash) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 24}:
+			if err := x.CheckID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			x.Amount = new(Amount)
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 10}:
+			x.DeliverMin = new(Amount)
+			if err := x.DeliverMin.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *CheckCancel) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrCheckID); err != nil {
+		return err
+	}
+	if err := x.CheckID.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *CheckCancel) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 24}:
+			if err := x.CheckID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SetDepositPreAuth) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if x.Authorize != nil {
+		if _, err := w.Write(hdrAuthorize); err != nil {
+			return err
+		}
+		if err := x.Authorize.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Unauthorize != nil {
+		if _, err := w.Write(hdrUnauthorize); err != nil {
+			return err
+		}
+		if err := x.Unauthorize.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SetDepositPreAuth) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 5}:
+			x.Authorize = new(Account)
+			if err := x.Authorize.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 6}:
+			x.Unauthorize = new(Account)
+			if err := x.Unauthorize.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *TrustSet) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.QualityIn != nil {
+		if _, err := w.Write(hdrQualityIn); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.QualityIn)); err != nil {
+			return err
+		}
+	}
+	if x.QualityOut != nil {
+		if _, err := w.Write(hdrQualityOut); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.QualityOut)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrLimitAmount); err != nil {
+		return err
+	}
+	if err := x.LimitAmount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *TrustSet) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 20}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.QualityIn = new(uint32)
+			*x.QualityIn = uint32(v)
+		case enc{2, 21}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.QualityOut = new(uint32)
+			*x.QualityOut = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 3}:
+			if err := x.LimitAmount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AccountDelete) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AccountDelete) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTokenMint) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TransferFee != nil {
+		if _, err := w.Write(hdrTransferFee); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(*x.TransferFee)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenTaxon != nil {
+		if _, err := w.Write(hdrNFTokenTaxon); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.NFTokenTaxon)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.URI != nil && len(*x.URI) > 0 {
+		if _, err := w.Write(hdrURI); err != nil {
+			return err
+		}
+		if err := x.URI.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if x.Issuer != nil {
+		if _, err := w.Write(hdrIssuer); err != nil {
+			return err
+		}
+		if err := x.Issuer.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTokenMint) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{1, 4}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TransferFee = new(uint16)
+			*x.TransferFee = uint16(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{2, 42}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.NFTokenTaxon = new(uint32)
+			*x.NFTokenTaxon = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 5}:
+			x.URI = new(VariableLength)
+			if err := x.URI.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 4}:
+			x.Issuer = new(Account)
+			if err := x.Issuer.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTokenBurn) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if x.Owner != nil {
+		if _, err := w.Write(hdrOwner); err != nil {
+			return err
+		}
+		if err := x.Owner.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTokenBurn) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 2}:
+			x.Owner = new(Account)
+			if err := x.Owner.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTokenCreateOffer) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenID != nil {
+		if _, err := w.Write(hdrNFTokenID); err != nil {
+			return err
+		}
+		if err := x.NFTokenID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Amount != nil {
+		if _, err := w.Write(hdrAmount); err != nil {
+			return err
+		}
+		if err := x.Amount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if x.Owner != nil {
+		if _, err := w.Write(hdrOwner); err != nil {
+			return err
+		}
+		if err := x.Owner.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Destination != nil {
+		if _, err := w.Write(hdrDestination); err != nil {
+			return err
+		}
+		if err := x.Destination.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTokenCreateOffer) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 10}:
+			x.NFTokenID = new(Hash256)
+			if err := x.NFTokenID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			x.Amount = new(Amount)
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 2}:
+			x.Owner = new(Account)
+			if err := x.Owner.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			x.Destination = new(Account)
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTCancelOffer) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenOffers != nil && len(*x.NFTokenOffers) > 0 {
+		if _, err := w.Write(hdrNFTokenOffers); err != nil {
+			return err
+		}
+		if err := x.NFTokenOffers.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTCancelOffer) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{19, 4}:
+			x.NFTokenOffers = new(Vector256)
+			if err := x.NFTokenOffers.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTAcceptOffer) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenBuyOffer != nil {
+		if _, err := w.Write(hdrNFTokenBuyOffer); err != nil {
+			return err
+		}
+		if err := x.NFTokenBuyOffer.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenSellOffer != nil {
+		if _, err := w.Write(hdrNFTokenSellOffer); err != nil {
+			return err
+		}
+		if err := x.NFTokenSellOffer.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.NFTokenBrokerFee != nil {
+		if _, err := w.Write(hdrNFTokenBrokerFee); err != nil {
+			return err
+		}
+		if err := x.NFTokenBrokerFee.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTAcceptOffer) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 28}:
+			x.NFTokenBuyOffer = new(Hash256)
+			if err := x.NFTokenBuyOffer.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 29}:
+			x.NFTokenSellOffer = new(Hash256)
+			if err := x.NFTokenSellOffer.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 19}:
+			x.NFTokenBrokerFee = new(Amount)
+			if err := x.NFTokenBrokerFee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Clawback) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Clawback) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AMMCreate) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrTradingFee); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TradingFee)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrAmount2); err != nil {
+		return err
+	}
+	if err := x.Amount2.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AMMCreate) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{1, 5}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TradingFee = uint16(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 11}:
+			if err := x.Amount2.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Amendment) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmendment); err != nil {
+		return err
+	}
+	if err := x.Amendment.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Amendment) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 19}:
+			if err := x.Amendment.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SetFee) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrReferenceFeeUnits); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.ReferenceFeeUnits)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrReserveBase); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.ReserveBase)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrReserveIncrement); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.ReserveIncrement)); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrBaseFee); err != nil {
+		return err
+	}
+	if err := writeUint64(w, uint64(x.BaseFee)); err != nil {
+		return err
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.BaseFeeDrops != nil {
+		if _, err := w.Write(hdrBaseFeeDrops); err != nil {
+			return err
+		}
+		if err := x.BaseFeeDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ReserveBaseDrops != nil {
+		if _, err := w.Write(hdrReserveBaseDrops); err != nil {
+			return err
+		}
+		if err := x.ReserveBaseDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ReserveIncrementDrops != nil {
+		if _, err := w.Write(hdrReserveIncrementDrops); err != nil {
+			return err
+		}
+		if err := x.ReserveIncrementDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SetFee) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{2, 30}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReferenceFeeUnits = uint32(v)
+		case enc{2, 31}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReserveBase = uint32(v)
+		case enc{2, 32}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReserveIncrement = uint32(v)
+		case enc{3, 5}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.BaseFee = Uint64Hex(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 22}:
+			x.BaseFeeDrops = new(Amount)
+			if err := x.BaseFeeDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 23}:
+			x.ReserveBaseDrops = new(Amount)
+			if err := x.ReserveBaseDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 24}:
+			x.ReserveIncrementDrops = new(Amount)
+			if err := x.ReserveIncrementDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *UNLModify) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.TxBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.TxBase.TransactionType)); err != nil {
+		return err
+	}
+	if x.TxBase.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrSequence); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TxBase.Sequence)); err != nil {
+		return err
+	}
+	if x.TxBase.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TxBase.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TxBase.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.TxBase.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrFee); err != nil {
+		return err
+	}
+	if err := x.TxBase.Fee.Marshal(w); err != nil {
+		return err
+	}
+	if x.TxBase.SigningPubKey != nil {
+		if _, err := w.Write(hdrSigningPubKey); err != nil {
+			return err
+		}
+		if err := x.TxBase.SigningPubKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if !ignoreSigningFields && x.TxBase.TxnSignature != nil && len(*x.TxBase.TxnSignature) > 0 {
+		if _, err := w.Write(hdrTxnSignature); err != nil {
+			return err
+		}
+		if err := x.TxBase.TxnSignature.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.UNLModifyValidator != nil && len(*x.UNLModifyValidator) > 0 {
+		if _, err := w.Write(hdrUNLModifyValidator); err != nil {
+			return err
+		}
+		if err := x.UNLModifyValidator.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.TxBase.Account.Marshal(w); err != nil {
+		return err
+	}
+	if !ignoreSigningFields && len(x.TxBase.Signers) > 0 {
+		if _, err := w.Write(hdrSigners); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Signers {
+			if err := x.TxBase.Signers[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if len(x.TxBase.Memos) > 0 {
+		if _, err := w.Write(hdrMemos); err != nil {
+			return err
+		}
+		for i := range x.TxBase.Memos {
+			if err := x.TxBase.Memos[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrUNLModifyDisabling); err != nil {
+		return err
+	}
+	if err := writeUint8(w, uint8(x.UNLModifyDisabling)); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *UNLModify) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 2}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.TransactionType = TransactionType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Flags = new(TransactionFlag)
+			*x.TxBase.Flags = TransactionFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.SourceTag = new(uint32)
+			*x.TxBase.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.Sequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TxBase.LastLedgerSequence = new(uint32)
+			*x.TxBase.LastLedgerSequence = uint32(v)
+		case enc{5, 5}:
+			x.TxBase.PreviousTxnID = new(Hash256)
+			if err := x.TxBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.TxBase.AccountTxnID = new(Hash256)
+			if err := x.TxBase.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 8}:
+			if err := x.TxBase.Fee.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 3}:
+			x.TxBase.SigningPubKey = new(PublicKey)
+			if err := x.TxBase.SigningPubKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 4}:
+			x.TxBase.TxnSignature = new(VariableLength)
+			if err := x.TxBase.TxnSignature.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 19}:
+			x.UNLModifyValidator = new(VariableLength)
+			if err := x.UNLModifyValidator.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.TxBase.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 3}:
+			s.push("Signers")
+			array, more, err := unmarshalSignerArray(r, s)
+			s.pop()
+			x.TxBase.Signers = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 9}:
+			s.push("Memos")
+			array, more, err := unmarshalMemoArray(r, s)
+			s.pop()
+			x.TxBase.Memos = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{16, 17}:
+			v, err := readUint8(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.UNLModifyDisabling = uint8(v)
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.TxBase.Unknown == nil {
+				x.TxBase.Unknown = make(UnknownFields)
+			}
+			if err := x.TxBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFTokenOffer) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenOfferNode != nil {
+		if _, err := w.Write(hdrNFTokenOfferNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.NFTokenOfferNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenID != nil {
+		if _, err := w.Write(hdrNFTokenID); err != nil {
+			return err
+		}
+		if err := x.NFTokenID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Amount != nil {
+		if _, err := w.Write(hdrAmount); err != nil {
+			return err
+		}
+		if err := x.Amount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Owner != nil {
+		if _, err := w.Write(hdrOwner); err != nil {
+			return err
+		}
+		if err := x.Owner.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Destination != nil {
+		if _, err := w.Write(hdrDestination); err != nil {
+			return err
+		}
+		if err := x.Destination.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTokenOffer) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{3, 12}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.NFTokenOfferNode = new(NodeIndex)
+			*x.NFTokenOfferNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 10}:
+			x.NFTokenID = new(Hash256)
+			if err := x.NFTokenID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			x.Amount = new(Amount)
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 2}:
+			x.Owner = new(Account)
+			if err := x.Owner.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			x.Destination = new(Account)
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Check) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if x.Sequence != nil {
+		if _, err := w.Write(hdrSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Sequence)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationNode != nil {
+		if _, err := w.Write(hdrDestinationNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.DestinationNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.InvoiceID != nil {
+		if _, err := w.Write(hdrInvoiceID); err != nil {
+			return err
+		}
+		if err := x.InvoiceID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.SendMax != nil {
+		if _, err := w.Write(hdrSendMax); err != nil {
+			return err
+		}
+		if err := x.SendMax.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Destination != nil {
+		if _, err := w.Write(hdrDestination); err != nil {
+			return err
+		}
+		if err := x.Destination.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Check) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SourceTag = new(uint32)
+			*x.SourceTag = uint32(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Sequence = new(uint32)
+			*x.Sequence = uint32(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{3, 9}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationNode = new(NodeIndex)
+			*x.DestinationNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 17}:
+			x.InvoiceID = new(Hash256)
+			if err := x.InvoiceID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 9}:
+			x.SendMax = new(Amount)
+			if err := x.SendMax.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			x.Destination = new(Account)
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NegativeUNL) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ValidatorToDisable != nil && len(*x.ValidatorToDisable) > 0 {
+		if _, err := w.Write(hdrValidatorToDisable); err != nil {
+			return err
+		}
+		if err := x.ValidatorToDisable.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ValidatorToReEnable != nil && len(*x.ValidatorToReEnable) > 0 {
+		if _, err := w.Write(hdrValidatorToReEnable); err != nil {
+			return err
+		}
+		if err := x.ValidatorToReEnable.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.DisabledValidators) > 0 {
+		if _, err := w.Write(hdrDisabledValidators); err != nil {
+			return err
+		}
+		for i := range x.DisabledValidators {
+			if err := x.DisabledValidators[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NegativeUNL) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 20}:
+			x.ValidatorToDisable = new(VariableLength)
+			if err := x.ValidatorToDisable.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 21}:
+			x.ValidatorToReEnable = new(VariableLength)
+			if err := x.ValidatorToReEnable.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 17}:
+			s.push("DisabledValidators")
+			array, more, err := unmarshalDisabledValidatorArray(r, s)
+			s.pop()
+			x.DisabledValidators = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *DisabledValidator) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.FirstLedgerSequence != nil {
+		if _, err := w.Write(hdrFirstLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.FirstLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.PublicKey != nil {
+		if _, err := w.Write(hdrPublicKey); err != nil {
+			return err
+		}
+		if err := x.PublicKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *DisabledValidator) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{2, 26}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FirstLedgerSequence = new(uint32)
+			*x.FirstLedgerSequence = uint32(v)
+		case enc{7, 1}:
+			x.PublicKey = new(PublicKey)
+			if err := x.PublicKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *NFTokenPage) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.PreviousPageMin != nil {
+		if _, err := w.Write(hdrPreviousPageMin); err != nil {
+			return err
+		}
+		if err := x.PreviousPageMin.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NextPageMin != nil {
+		if _, err := w.Write(hdrNextPageMin); err != nil {
+			return err
+		}
+		if err := x.NextPageMin.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.NFTokens) > 0 {
+		if _, err := w.Write(hdrNFTokens); err != nil {
+			return err
+		}
+		for i := range x.NFTokens {
+			if err := x.NFTokens[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFTokenPage) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 26}:
+			x.PreviousPageMin = new(Hash256)
+			if err := x.PreviousPageMin.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 27}:
+			x.NextPageMin = new(Hash256)
+			if err := x.NextPageMin.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 10}:
+			s.push("NFTokens")
+			array, more, err := unmarshalNFTokenArray(r, s)
+			s.pop()
+			x.NFTokens = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *NFToken) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.NFTokenID != nil {
+		if _, err := w.Write(hdrNFTokenID); err != nil {
+			return err
+		}
+		if err := x.NFTokenID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.URI != nil && len(*x.URI) > 0 {
+		if _, err := w.Write(hdrURI); err != nil {
+			return err
+		}
+		if err := x.URI.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NFToken) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{5, 10}:
+			x.NFTokenID = new(Hash256)
+			if err := x.NFTokenID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 5}:
+			x.URI = new(VariableLength)
+			if err := x.URI.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *Oracle) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Oracle) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *SignerList) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.SignerQuorum != nil {
+		if _, err := w.Write(hdrSignerQuorum); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SignerQuorum)); err != nil {
+			return err
+		}
+	}
+	if x.SignerListID != nil {
+		if _, err := w.Write(hdrSignerListID); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SignerListID)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.SignerEntries) > 0 {
+		if _, err := w.Write(hdrSignerEntries); err != nil {
+			return err
+		}
+		for i := range x.SignerEntries {
+			if err := x.SignerEntries[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *SignerList) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 35}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SignerQuorum = new(uint32)
+			*x.SignerQuorum = uint32(v)
+		case enc{2, 38}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SignerListID = new(uint32)
+			*x.SignerListID = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 4}:
+			s.push("SignerEntries")
+			array, more, err := unmarshalSignerEntryArray(r, s)
+			s.pop()
+			x.SignerEntries = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Ticket) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.TicketSequence != nil {
+		if _, err := w.Write(hdrTicketSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketSequence)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Ticket) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 41}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketSequence = new(uint32)
+			*x.TicketSequence = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AccountRoot) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.Sequence != nil {
+		if _, err := w.Write(hdrSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Sequence)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.TransferRate != nil {
+		if _, err := w.Write(hdrTransferRate); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TransferRate)); err != nil {
+			return err
+		}
+	}
+	if x.WalletSize != nil {
+		if _, err := w.Write(hdrWalletSize); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.WalletSize)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerCount != nil {
+		if _, err := w.Write(hdrOwnerCount); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.OwnerCount)); err != nil {
+			return err
+		}
+	}
+	if x.TicketCount != nil {
+		if _, err := w.Write(hdrTicketCount); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.TicketCount)); err != nil {
+			return err
+		}
+	}
+	if x.MintedNFTokens != nil {
+		if _, err := w.Write(hdrMintedNFTokens); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.MintedNFTokens)); err != nil {
+			return err
+		}
+	}
+	if x.BurnedNFTokens != nil {
+		if _, err := w.Write(hdrBurnedNFTokens); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.BurnedNFTokens)); err != nil {
+			return err
+		}
+	}
+	if x.FirstNFTokenSequence != nil {
+		if _, err := w.Write(hdrFirstNFTokenSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.FirstNFTokenSequence)); err != nil {
+			return err
+		}
+	}
+	if x.EmailHash != nil {
+		if _, err := w.Write(hdrEmailHash); err != nil {
+			return err
+		}
+		if err := x.EmailHash.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.WalletLocator != nil {
+		if _, err := w.Write(hdrWalletLocator); err != nil {
+			return err
+		}
+		if err := x.WalletLocator.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.AccountTxnID != nil {
+		if _, err := w.Write(hdrAccountTxnID); err != nil {
+			return err
+		}
+		if err := x.AccountTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.AMMID != nil {
+		if _, err := w.Write(hdrAMMID); err != nil {
+			return err
+		}
+		if err := x.AMMID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Balance != nil {
+		if _, err := w.Write(hdrBalance); err != nil {
+			return err
+		}
+		if err := x.Balance.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.MessageKey != nil && len(*x.MessageKey) > 0 {
+		if _, err := w.Write(hdrMessageKey); err != nil {
+			return err
+		}
+		if err := x.MessageKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Domain != nil && len(*x.Domain) > 0 {
+		if _, err := w.Write(hdrDomain); err != nil {
+			return err
+		}
+		if err := x.Domain.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.RegularKey != nil {
+		if _, err := w.Write(hdrRegularKey); err != nil {
+			return err
+		}
+		if err := x.RegularKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenMinter != nil {
+		if _, err := w.Write(hdrNFTokenMinter); err != nil {
+			return err
+		}
+		if err := x.NFTokenMinter.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TickSize != nil {
+		if _, err := w.Write(hdrTickSize); err != nil {
+			return err
+		}
+		if err := writeUint8(w, uint8(*x.TickSize)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AccountRoot) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Sequence = new(uint32)
+			*x.Sequence = uint32(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 11}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TransferRate = new(uint32)
+			*x.TransferRate = uint32(v)
+		case enc{2, 12}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.WalletSize = new(uint32)
+			*x.WalletSize = uint32(v)
+		case enc{2, 13}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerCount = new(uint32)
+			*x.OwnerCount = uint32(v)
+		case enc{2, 40}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TicketCount = new(uint32)
+			*x.TicketCount = uint32(v)
+		case enc{2, 43}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.MintedNFTokens = new(uint32)
+			*x.MintedNFTokens = uint32(v)
+		case enc{2, 44}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.BurnedNFTokens = new(uint32)
+			*x.BurnedNFTokens = uint32(v)
+		case enc{2, 50}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FirstNFTokenSequence = new(uint32)
+			*x.FirstNFTokenSequence = uint32(v)
+		case enc{4, 1}:
+			x.EmailHash = new(Hash128)
+			if err := x.EmailHash.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 7}:
+			x.WalletLocator = new(Hash256)
+			if err := x.WalletLocator.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 9}:
+			x.AccountTxnID = new(Hash256)
+			if err := x.AccountTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 14}:
+			x.AMMID = new(Hash256)
+			if err := x.AMMID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 2}:
+			x.Balance = new(Value)
+			if err := x.Balance.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 2}:
+			x.MessageKey = new(VariableLength)
+			if err := x.MessageKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 7}:
+			x.Domain = new(VariableLength)
+			if err := x.Domain.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 8}:
+			x.RegularKey = new(RegularKey)
+			if err := x.RegularKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 9}:
+			x.NFTokenMinter = new(Account)
+			if err := x.NFTokenMinter.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{16, 16}:
+			v, err := readUint8(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TickSize = new(uint8)
+			*x.TickSize = uint8(v)
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Directory) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.IndexNext != nil {
+		if _, err := w.Write(hdrIndexNext); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.IndexNext)); err != nil {
+			return err
+		}
+	}
+	if x.IndexPrevious != nil {
+		if _, err := w.Write(hdrIndexPrevious); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.IndexPrevious)); err != nil {
+			return err
+		}
+	}
+	if x.ExchangeRate != nil {
+		if _, err := w.Write(hdrExchangeRate); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.ExchangeRate)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.RootIndex != nil {
+		if _, err := w.Write(hdrRootIndex); err != nil {
+			return err
+		}
+		if err := x.RootIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.NFTokenID != nil {
+		if _, err := w.Write(hdrNFTokenID); err != nil {
+			return err
+		}
+		if err := x.NFTokenID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Owner != nil {
+		if _, err := w.Write(hdrOwner); err != nil {
+			return err
+		}
+		if err := x.Owner.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerPaysCurrency != nil {
+		if _, err := w.Write(hdrTakerPaysCurrency); err != nil {
+			return err
+		}
+		if err := x.TakerPaysCurrency.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerPaysIssuer != nil {
+		if _, err := w.Write(hdrTakerPaysIssuer); err != nil {
+			return err
+		}
+		if err := x.TakerPaysIssuer.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerGetsCurrency != nil {
+		if _, err := w.Write(hdrTakerGetsCurrency); err != nil {
+			return err
+		}
+		if err := x.TakerGetsCurrency.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerGetsIssuer != nil {
+		if _, err := w.Write(hdrTakerGetsIssuer); err != nil {
+			return err
+		}
+		if err := x.TakerGetsIssuer.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Indexes != nil && len(*x.Indexes) > 0 {
+		if _, err := w.Write(hdrIndexes); err != nil {
+			return err
+		}
+		if err := x.Indexes.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Directory) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{3, 1}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.IndexNext = new(NodeIndex)
+			*x.IndexNext = NodeIndex(v)
+		case enc{3, 2}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.IndexPrevious = new(NodeIndex)
+			*x.IndexPrevious = NodeIndex(v)
+		case enc{3, 6}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ExchangeRate = new(ExchangeRate)
+			*x.ExchangeRate = ExchangeRate(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 8}:
+			x.RootIndex = new(Hash256)
+			if err := x.RootIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 10}:
+			x.NFTokenID = new(Hash256)
+			if err := x.NFTokenID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 2}:
+			x.Owner = new(Account)
+			if err := x.Owner.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{17, 1}:
+			x.TakerPaysCurrency = new(Hash160)
+			if err := x.TakerPaysCurrency.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{17, 2}:
+			x.TakerPaysIssuer = new(Hash160)
+			if err := x.TakerPaysIssuer.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{17, 3}:
+			x.TakerGetsCurrency = new(Hash160)
+			if err := x.TakerGetsCurrency.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{17, 4}:
+			x.TakerGetsIssuer = new(Hash160)
+			if err := x.TakerGetsIssuer.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{19, 1}:
+			x.Indexes = new(Vector256)
+			if err := x.Indexes.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Amendments) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.Majorities) > 0 {
+		if _, err := w.Write(hdrMajorities); err != nil {
+			return err
+		}
+		for i := range x.Majorities {
+			if err := x.Majorities[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if x.Amendments != nil && len(*x.Amendments) > 0 {
+		if _, err := w.Write(hdrAmendments); err != nil {
+			return err
+		}
+		if err := x.Amendments.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Amendments) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 16}:
+			s.push("Majorities")
+			array, more, err := unmarshalMajorityArray(r, s)
+			s.pop()
+			x.Majorities = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{19, 3}:
+			x.Amendments = new(Vector256)
+			if err := x.Amendments.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Majority) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.CloseTime != nil {
+		if _, err := w.Write(hdrCloseTime); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.CloseTime)); err != nil {
+			return err
+		}
+	}
+	if x.Amendment != nil {
+		if _, err := w.Write(hdrAmendment); err != nil {
+			return err
+		}
+		if err := x.Amendment.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Majority) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{2, 7}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.CloseTime = new(uint32)
+			*x.CloseTime = uint32(v)
+		case enc{5, 19}:
+			x.Amendment = new(Hash256)
+			if err := x.Amendment.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *LedgerHashes) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.FirstLedgerSequence != nil {
+		if _, err := w.Write(hdrFirstLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.FirstLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.LastLedgerSequence != nil {
+		if _, err := w.Write(hdrLastLedgerSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.LastLedgerSequence)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Hashes != nil && len(*x.Hashes) > 0 {
+		if _, err := w.Write(hdrHashes); err != nil {
+			return err
+		}
+		if err := x.Hashes.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *LedgerHashes) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 26}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FirstLedgerSequence = new(uint32)
+			*x.FirstLedgerSequence = uint32(v)
+		case enc{2, 27}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.LastLedgerSequence = new(uint32)
+			*x.LastLedgerSequence = uint32(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{19, 2}:
+			x.Hashes = new(Vector256)
+			if err := x.Hashes.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Offer) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.Sequence != nil {
+		if _, err := w.Write(hdrSequence); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Sequence)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.BookNode != nil {
+		if _, err := w.Write(hdrBookNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.BookNode)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.BookDirectory != nil {
+		if _, err := w.Write(hdrBookDirectory); err != nil {
+			return err
+		}
+		if err := x.BookDirectory.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerPays != nil {
+		if _, err := w.Write(hdrTakerPays); err != nil {
+			return err
+		}
+		if err := x.TakerPays.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.TakerGets != nil {
+		if _, err := w.Write(hdrTakerGets); err != nil {
+			return err
+		}
+		if err := x.TakerGets.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *Offer) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 4}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Sequence = new(uint32)
+			*x.Sequence = uint32(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{3, 3}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.BookNode = new(NodeIndex)
+			*x.BookNode = NodeIndex(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 16}:
+			x.BookDirectory = new(Hash256)
+			if err := x.BookDirectory.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 4}:
+			x.TakerPays = new(Amount)
+			if err := x.TakerPays.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 5}:
+			x.TakerGets = new(Amount)
+			if err := x.TakerGets.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *DepositPreAuth) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Authorize != nil {
+		if _, err := w.Write(hdrAuthorize); err != nil {
+			return err
+		}
+		if err := x.Authorize.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *DepositPreAuth) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 5}:
+			x.Authorize = new(Account)
+			if err := x.Authorize.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *RippleState) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.HighQualityIn != nil {
+		if _, err := w.Write(hdrHighQualityIn); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.HighQualityIn)); err != nil {
+			return err
+		}
+	}
+	if x.HighQualityOut != nil {
+		if _, err := w.Write(hdrHighQualityOut); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.HighQualityOut)); err != nil {
+			return err
+		}
+	}
+	if x.LowQualityIn != nil {
+		if _, err := w.Write(hdrLowQualityIn); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.LowQualityIn)); err != nil {
+			return err
+		}
+	}
+	if x.LowQualityOut != nil {
+		if _, err := w.Write(hdrLowQualityOut); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.LowQualityOut)); err != nil {
+			return err
+		}
+	}
+	if x.LowNode != nil {
+		if _, err := w.Write(hdrLowNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.LowNode)); err != nil {
+			return err
+		}
+	}
+	if x.HighNode != nil {
+		if _, err := w.Write(hdrHighNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.HighNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Balance != nil {
+		if _, err := w.Write(hdrBalance); err != nil {
+			return err
+		}
+		if err := x.Balance.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.LowLimit != nil {
+		if _, err := w.Write(hdrLowLimit); err != nil {
+			return err
+		}
+		if err := x.LowLimit.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.HighLimit != nil {
+		if _, err := w.Write(hdrHighLimit); err != nil {
+			return err
+		}
+		if err := x.HighLimit.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *RippleState) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 16}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.HighQualityIn = new(uint32)
+			*x.HighQualityIn = uint32(v)
+		case enc{2, 17}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.HighQualityOut = new(uint32)
+			*x.HighQualityOut = uint32(v)
+		case enc{2, 18}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.LowQualityIn = new(uint32)
+			*x.LowQualityIn = uint32(v)
+		case enc{2, 19}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.LowQualityOut = new(uint32)
+			*x.LowQualityOut = uint32(v)
+		case enc{3, 7}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.LowNode = new(NodeIndex)
+			*x.LowNode = NodeIndex(v)
+		case enc{3, 8}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.HighNode = new(NodeIndex)
+			*x.HighNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 2}:
+			x.Balance = new(Amount)
+			if err := x.Balance.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 6}:
+			x.LowLimit = new(Amount)
+			if err := x.LowLimit.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 7}:
+			x.HighLimit = new(Amount)
+			if err := x.HighLimit.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *FeeSettings) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.ReferenceFeeUnits != nil {
+		if _, err := w.Write(hdrReferenceFeeUnits); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.ReferenceFeeUnits)); err != nil {
+			return err
+		}
+	}
+	if x.ReserveBase != nil {
+		if _, err := w.Write(hdrReserveBase); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.ReserveBase)); err != nil {
+			return err
+		}
+	}
+	if x.ReserveIncrement != nil {
+		if _, err := w.Write(hdrReserveIncrement); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.ReserveIncrement)); err != nil {
+			return err
+		}
+	}
+	if x.BaseFee != nil {
+		if _, err := w.Write(hdrBaseFee); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.BaseFee)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.BaseFeeDrops != nil {
+		if _, err := w.Write(hdrBaseFeeDrops); err != nil {
+			return err
+		}
+		if err := x.BaseFeeDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ReserveBaseDrops != nil {
+		if _, err := w.Write(hdrReserveBaseDrops); err != nil {
+			return err
+		}
+		if err := x.ReserveBaseDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.ReserveIncrementDrops != nil {
+		if _, err := w.Write(hdrReserveIncrementDrops); err != nil {
+			return err
+		}
+		if err := x.ReserveIncrementDrops.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *FeeSettings) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 30}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReferenceFeeUnits = new(uint32)
+			*x.ReferenceFeeUnits = uint32(v)
+		case enc{2, 31}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReserveBase = new(uint32)
+			*x.ReserveBase = uint32(v)
+		case enc{2, 32}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.ReserveIncrement = new(uint32)
+			*x.ReserveIncrement = uint32(v)
+		case enc{3, 5}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.BaseFee = new(Uint64Hex)
+			*x.BaseFee = Uint64Hex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 22}:
+			x.BaseFeeDrops = new(Amount)
+			if err := x.BaseFeeDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 23}:
+			x.ReserveBaseDrops = new(Amount)
+			if err := x.ReserveBaseDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 24}:
+			x.ReserveIncrementDrops = new(Amount)
+			if err := x.ReserveIncrementDrops.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *Escrow) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.CancelAfter != nil {
+		if _, err := w.Write(hdrCancelAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.CancelAfter)); err != nil {
+			return err
+		}
+	}
+	if x.FinishAfter != nil {
+		if _, err := w.Write(hdrFinishAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.FinishAfter)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationNode != nil {
+		if _, err := w.Write(hdrDestinationNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.DestinationNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAmount); err != nil {
+		return err
+	}
+	if err := x.Amount.Marshal(w); err != nil {
+		return err
+	}
+	if x.Condition != nil && len(*x.Condition) > 0 {
+		if _, err := w.Write(hdrCondition); err != nil {
+			return err
+		}
+		if err := x.Condition.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.Account.Marshal(w); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrDestination); err != nil {
+		return err
+	}
+	if err := x.Destination.Marshal(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *Escrow) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SourceTag = new(uint32)
+			*x.SourceTag = uint32(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 36}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.CancelAfter = new(uint32)
+			*x.CancelAfter = uint32(v)
+		case enc{2, 37}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FinishAfter = new(uint32)
+			*x.FinishAfter = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{3, 9}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationNode = new(NodeIndex)
+			*x.DestinationNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 17}:
+			x.Condition = new(VariableLength)
+			if err := x.Condition.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *PayChannel) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.SourceTag != nil {
+		if _, err := w.Write(hdrSourceTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SourceTag)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationTag != nil {
+		if _, err := w.Write(hdrDestinationTag); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.DestinationTag)); err != nil {
+			return err
+		}
+	}
+	if x.CancelAfter != nil {
+		if _, err := w.Write(hdrCancelAfter); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.CancelAfter)); err != nil {
+			return err
+		}
+	}
+	if x.SettleDelay != nil {
+		if _, err := w.Write(hdrSettleDelay); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.SettleDelay)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.DestinationNode != nil {
+		if _, err := w.Write(hdrDestinationNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.DestinationNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Amount != nil {
+		if _, err := w.Write(hdrAmount); err != nil {
+			return err
+		}
+		if err := x.Amount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Balance != nil {
+		if _, err := w.Write(hdrBalance); err != nil {
+			return err
+		}
+		if err := x.Balance.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.PublicKey != nil {
+		if _, err := w.Write(hdrPublicKey); err != nil {
+			return err
+		}
+		if err := x.PublicKey.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Destination != nil {
+		if _, err := w.Write(hdrDestination); err != nil {
+			return err
+		}
+		if err := x.Destination.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *PayChannel) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 3}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SourceTag = new(uint32)
+			*x.SourceTag = uint32(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{2, 14}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationTag = new(uint32)
+			*x.DestinationTag = uint32(v)
+		case enc{2, 36}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.CancelAfter = new(uint32)
+			*x.CancelAfter = uint32(v)
+		case enc{2, 39}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.SettleDelay = new(uint32)
+			*x.SettleDelay = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{3, 9}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DestinationNode = new(NodeIndex)
+			*x.DestinationNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 1}:
+			x.Amount = new(Amount)
+			if err := x.Amount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 2}:
+			x.Balance = new(Amount)
+			if err := x.Balance.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{7, 1}:
+			x.PublicKey = new(PublicKey)
+			if err := x.PublicKey.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 3}:
+			x.Destination = new(Account)
+			if err := x.Destination.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AMM) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.leBase.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if !inner {
+		if _, err := w.Write(hdrLedgerEntryType); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(x.leBase.LedgerEntryType)); err != nil {
+			return err
+		}
+	}
+	if x.TradingFee != nil {
+		if _, err := w.Write(hdrTradingFee); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(*x.TradingFee)); err != nil {
+			return err
+		}
+	}
+	if x.Flags != nil {
+		if _, err := w.Write(hdrFlags); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Flags)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.leBase.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.OwnerNode != nil {
+		if _, err := w.Write(hdrOwnerNode); err != nil {
+			return err
+		}
+		if err := writeUint64(w, uint64(*x.OwnerNode)); err != nil {
+			return err
+		}
+	}
+	if x.leBase.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.leBase.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.leBase.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.leBase.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.LPTokenBalance != nil {
+		if _, err := w.Write(hdrLPTokenBalance); err != nil {
+			return err
+		}
+		if err := x.LPTokenBalance.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.AuctionSlot != nil {
+		if _, err := w.Write(hdrAuctionSlot); err != nil {
+			return err
+		}
+		if err := x.AuctionSlot.marshal(w, ignoreSigningFields, true); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	if len(x.VoteSlots) > 0 {
+		if _, err := w.Write(hdrVoteSlots); err != nil {
+			return err
+		}
+		for i := range x.VoteSlots {
+			if err := x.VoteSlots[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if x.Asset != nil {
+		if _, err := w.Write(hdrAsset); err != nil {
+			return err
+		}
+		if err := x.Asset.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Asset2 != nil {
+		if _, err := w.Write(hdrAsset2); err != nil {
+			return err
+		}
+		if err := x.Asset2.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AMM) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.LedgerEntryType = LedgerEntryType(v)
+		case enc{1, 5}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TradingFee = new(uint16)
+			*x.TradingFee = uint16(v)
+		case enc{2, 2}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Flags = new(LedgerEntryFlag)
+			*x.Flags = LedgerEntryFlag(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.leBase.PreviousTxnLgrSeq = new(uint32)
+			*x.leBase.PreviousTxnLgrSeq = uint32(v)
+		case enc{3, 4}:
+			v, err := readUint64(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.OwnerNode = new(NodeIndex)
+			*x.OwnerNode = NodeIndex(v)
+		case enc{5, 5}:
+			x.leBase.PreviousTxnID = new(Hash256)
+			if err := x.leBase.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.leBase.LedgerIndex = new(Hash256)
+			if err := x.leBase.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{6, 31}:
+			x.LPTokenBalance = new(Amount)
+			if err := x.LPTokenBalance.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 26}:
+			x.AuctionSlot = new(AuctionSlot)
+			s.push("AuctionSlot")
+			err := x.AuctionSlot.unmarshal(r, s)
+			s.pop()
+			if err != errorEndOfObject {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 12}:
+			s.push("VoteSlots")
+			array, more, err := unmarshalVoteEntryArray(r, s)
+			s.pop()
+			x.VoteSlots = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{24, 3}:
+			x.Asset = new(Issue)
+			if err := x.Asset.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{24, 4}:
+			x.Asset2 = new(Issue)
+			if err := x.Asset2.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.leBase.Unknown == nil {
+				x.leBase.Unknown = make(UnknownFields)
+			}
+			if err := x.leBase.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AuctionSlot) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.DiscountedFee != nil {
+		if _, err := w.Write(hdrDiscountedFee); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(*x.DiscountedFee)); err != nil {
+			return err
+		}
+	}
+	if x.Expiration != nil {
+		if _, err := w.Write(hdrExpiration); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.Expiration)); err != nil {
+			return err
+		}
+	}
+	if x.Price != nil {
+		if _, err := w.Write(hdrPrice); err != nil {
+			return err
+		}
+		if err := x.Price.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.AuthAccounts) > 0 {
+		if _, err := w.Write(hdrAuthAccounts); err != nil {
+			return err
+		}
+		for i := range x.AuthAccounts {
+			if err := x.AuthAccounts[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AuctionSlot) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 6}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.DiscountedFee = new(uint16)
+			*x.DiscountedFee = uint16(v)
+		case enc{2, 10}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.Expiration = new(uint32)
+			*x.Expiration = uint32(v)
+		case enc{6, 28}:
+			x.Price = new(Amount)
+			if err := x.Price.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 25}:
+			s.push("AuthAccounts")
+			array, more, err := unmarshalAuthAccountArray(r, s)
+			s.pop()
+			x.AuthAccounts = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *AuthAccountItem) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if _, err := w.Write(hdrAccount); err != nil {
+		return err
+	}
+	if err := x.Account.Marshal(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *AuthAccountItem) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{8, 1}:
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *VoteEntryItem) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if x.TradingFee != nil {
+		if _, err := w.Write(hdrTradingFee); err != nil {
+			return err
+		}
+		if err := writeUint16(w, uint16(*x.TradingFee)); err != nil {
+			return err
+		}
+	}
+	if x.VoteWeight != nil {
+		if _, err := w.Write(hdrVoteWeight); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.VoteWeight)); err != nil {
+			return err
+		}
+	}
+	if x.Account != nil {
+		if _, err := w.Write(hdrAccount); err != nil {
+			return err
+		}
+		if err := x.Account.Marshal(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *VoteEntryItem) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 5}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TradingFee = new(uint16)
+			*x.TradingFee = uint16(v)
+		case enc{2, 48}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.VoteWeight = new(uint32)
+			*x.VoteWeight = uint32(v)
+		case enc{8, 1}:
+			x.Account = new(Account)
+			if err := x.Account.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *MetaData) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if len(x.Unknown) > 0 {
+		return encodeReflect(w, x, ignoreSigningFields, inner)
+	}
+	if _, err := w.Write(hdrTransactionIndex); err != nil {
+		return err
+	}
+	if err := writeUint32(w, uint32(x.TransactionIndex)); err != nil {
+		return err
+	}
+	if x.DeliveredAmount != nil {
+		if _, err := w.Write(hdrDeliveredAmount); err != nil {
+			return err
+		}
+		if err := x.DeliveredAmount.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if len(x.AffectedNodes) > 0 {
+		if _, err := w.Write(hdrAffectedNodes); err != nil {
+			return err
+		}
+		for i := range x.AffectedNodes {
+			if err := x.AffectedNodes[i].marshalElement(w, ignoreSigningFields); err != nil {
+				return err
+			}
+		}
+		if _, err := w.Write(hdrEndOfArray); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write(hdrTransactionResult); err != nil {
+		return err
+	}
+	if err := x.TransactionResult.Marshal(w); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *MetaData) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{2, 28}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.TransactionIndex = uint32(v)
+		case enc{6, 18}:
+			x.DeliveredAmount = new(Amount)
+			if err := x.DeliveredAmount.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{15, 8}:
+			s.push("AffectedNodes")
+			array, more, err := unmarshalNodeEffectArray(r, s)
+			s.pop()
+			x.AffectedNodes = array
+			if !more {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{16, 3}:
+			if err := x.TransactionResult.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			if x.Unknown == nil {
+				x.Unknown = make(UnknownFields)
+			}
+			if err := x.Unknown.read(r, e); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		}
+	}
+}
+
+func (x *AffectedNode) marshal(w io.Writer, ignoreSigningFields, inner bool) error {
+	if _, err := w.Write(hdrLedgerEntryType); err != nil {
+		return err
+	}
+	if err := writeUint16(w, uint16(x.LedgerEntryType)); err != nil {
+		return err
+	}
+	if x.PreviousTxnLgrSeq != nil {
+		if _, err := w.Write(hdrPreviousTxnLgrSeq); err != nil {
+			return err
+		}
+		if err := writeUint32(w, uint32(*x.PreviousTxnLgrSeq)); err != nil {
+			return err
+		}
+	}
+	if x.PreviousTxnID != nil {
+		if _, err := w.Write(hdrPreviousTxnID); err != nil {
+			return err
+		}
+		if err := x.PreviousTxnID.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.LedgerIndex != nil {
+		if _, err := w.Write(hdrLedgerIndex); err != nil {
+			return err
+		}
+		if err := x.LedgerIndex.Marshal(w); err != nil {
+			return err
+		}
+	}
+	if x.PreviousFields != nil {
+		if _, err := w.Write(hdrPreviousFields); err != nil {
+			return err
+		}
+		if err := encodeInner(w, x.PreviousFields, ignoreSigningFields); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	if x.FinalFields != nil {
+		if _, err := w.Write(hdrFinalFields); err != nil {
+			return err
+		}
+		if err := encodeInner(w, x.FinalFields, ignoreSigningFields); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	if x.NewFields != nil {
+		if _, err := w.Write(hdrNewFields); err != nil {
+			return err
+		}
+		if err := encodeInner(w, x.NewFields, ignoreSigningFields); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *AffectedNode) unmarshal(r Reader, s *decodeState) error {
+	for {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return nil
+		}
+		switch e {
+		case enc{1, 1}:
+			v, err := readUint16(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.LedgerEntryType = LedgerEntryType(v)
+		case enc{2, 5}:
+			v, err := readUint32(r)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.PreviousTxnLgrSeq = new(uint32)
+			*x.PreviousTxnLgrSeq = uint32(v)
+		case enc{5, 5}:
+			x.PreviousTxnID = new(Hash256)
+			if err := x.PreviousTxnID.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{5, 6}:
+			x.LedgerIndex = new(Hash256)
+			if err := x.LedgerIndex.Unmarshal(r); err != nil {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 6}:
+			le, err := newLedgerEntry(x.LedgerEntryType)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.PreviousFields = le
+			s.push("PreviousFields")
+			err = decodeObject(r, le, s)
+			s.pop()
+			if err != nil && err != errorEndOfObject {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 7}:
+			le, err := newLedgerEntry(x.LedgerEntryType)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.FinalFields = le
+			s.push("FinalFields")
+			err = decodeObject(r, le, s)
+			s.pop()
+			if err != nil && err != errorEndOfObject {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 8}:
+			le, err := newLedgerEntry(x.LedgerEntryType)
+			if err != nil {
+				return s.fieldError(offset, e, err)
+			}
+			x.NewFields = le
+			s.push("NewFields")
+			err = decodeObject(r, le, s)
+			s.pop()
+			if err != nil && err != errorEndOfObject {
+				return s.fieldError(offset, e, err)
+			}
+		case enc{14, 1}:
+			return errorEndOfObject
+		case enc{15, 1}:
+			return errorEndOfArray
+		default:
+			return s.fieldError(offset, e, fmt.Errorf("Missing field: %s %+v", encodings[e], e))
+		}
+	}
+}
+
+func (x *Signer) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrSigner); err != nil {
+		return err
+	}
+	if err := x.Signer.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrEndOfObject); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *Signer) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 16}:
+		s.push("Signer")
+		defer s.pop()
+		return x.Signer.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in Signer", encodings[e]))
+	}
+}
+
+func unmarshalSignerArray(r Reader, s *decodeState) ([]Signer, bool, error) {
+	var array []Signer
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element Signer
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *Memo) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrMemo); err != nil {
+		return err
+	}
+	if err := x.Memo.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrEndOfObject); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *Memo) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 10}:
+		s.push("Memo")
+		defer s.pop()
+		return x.Memo.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in Memo", encodings[e]))
+	}
+}
+
+func unmarshalMemoArray(r Reader, s *decodeState) ([]Memo, bool, error) {
+	var array []Memo
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element Memo
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *SignerEntry) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrSignerEntry); err != nil {
+		return err
+	}
+	if err := x.SignerEntry.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrEndOfObject); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *SignerEntry) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 11}:
+		s.push("SignerEntry")
+		defer s.pop()
+		return x.SignerEntry.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in SignerEntry", encodings[e]))
+	}
+}
+
+func unmarshalSignerEntryArray(r Reader, s *decodeState) ([]SignerEntry, bool, error) {
+	var array []SignerEntry
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element SignerEntry
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *DisabledValidator) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrDisabledValidator); err != nil {
+		return err
+	}
+	if err := x.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	_, err := w.Write(hdrEndOfObject)
+	return err
+}
+
+func (x *DisabledValidator) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 19}:
+		s.push("DisabledValidator")
+		defer s.pop()
+		return x.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in DisabledValidator", encodings[e]))
+	}
+}
+
+func unmarshalDisabledValidatorArray(r Reader, s *decodeState) ([]DisabledValidator, bool, error) {
+	var array []DisabledValidator
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element DisabledValidator
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *NFToken) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrNFToken); err != nil {
+		return err
+	}
+	if err := x.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	_, err := w.Write(hdrEndOfObject)
+	return err
+}
+
+func (x *NFToken) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 12}:
+		s.push("NFToken")
+		defer s.pop()
+		return x.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in NFToken", encodings[e]))
+	}
+}
+
+func unmarshalNFTokenArray(r Reader, s *decodeState) ([]NFToken, bool, error) {
+	var array []NFToken
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element NFToken
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *Majority) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrMajority); err != nil {
+		return err
+	}
+	if err := x.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	_, err := w.Write(hdrEndOfObject)
+	return err
+}
+
+func (x *Majority) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 18}:
+		s.push("Majority")
+		defer s.pop()
+		return x.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in Majority", encodings[e]))
+	}
+}
+
+func unmarshalMajorityArray(r Reader, s *decodeState) ([]Majority, bool, error) {
+	var array []Majority
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element Majority
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *AuthAccount) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrAuthAccount); err != nil {
+		return err
+	}
+	if err := x.AuthAccount.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrEndOfObject); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *AuthAccount) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 27}:
+		s.push("AuthAccount")
+		defer s.pop()
+		return x.AuthAccount.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in AuthAccount", encodings[e]))
+	}
+}
+
+func unmarshalAuthAccountArray(r Reader, s *decodeState) ([]AuthAccount, bool, error) {
+	var array []AuthAccount
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element AuthAccount
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *VoteEntry) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if _, err := w.Write(hdrVoteEntry); err != nil {
+		return err
+	}
+	if err := x.VoteEntry.marshal(w, ignoreSigningFields, true); err != nil {
+		return err
+	}
+	if _, err := w.Write(hdrEndOfObject); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (x *VoteEntry) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 25}:
+		s.push("VoteEntry")
+		defer s.pop()
+		return x.VoteEntry.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in VoteEntry", encodings[e]))
+	}
+}
+
+func unmarshalVoteEntryArray(r Reader, s *decodeState) ([]VoteEntry, bool, error) {
+	var array []VoteEntry
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element VoteEntry
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
+
+func (x *NodeEffect) marshalElement(w io.Writer, ignoreSigningFields bool) error {
+	if x.CreatedNode != nil {
+		if _, err := w.Write(hdrCreatedNode); err != nil {
+			return err
+		}
+		if err := x.CreatedNode.marshal(w, ignoreSigningFields, true); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	if x.DeletedNode != nil {
+		if _, err := w.Write(hdrDeletedNode); err != nil {
+			return err
+		}
+		if err := x.DeletedNode.marshal(w, ignoreSigningFields, true); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	if x.ModifiedNode != nil {
+		if _, err := w.Write(hdrModifiedNode); err != nil {
+			return err
+		}
+		if err := x.ModifiedNode.marshal(w, ignoreSigningFields, true); err != nil {
+			return err
+		}
+		if _, err := w.Write(hdrEndOfObject); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (x *NodeEffect) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {
+	switch e {
+	case enc{14, 3}:
+		x.CreatedNode = new(AffectedNode)
+		s.push("CreatedNode")
+		defer s.pop()
+		return x.CreatedNode.unmarshal(r, s)
+	case enc{14, 4}:
+		x.DeletedNode = new(AffectedNode)
+		s.push("DeletedNode")
+		defer s.pop()
+		return x.DeletedNode.unmarshal(r, s)
+	case enc{14, 5}:
+		x.ModifiedNode = new(AffectedNode)
+		s.push("ModifiedNode")
+		defer s.pop()
+		return x.ModifiedNode.unmarshal(r, s)
+	case enc{14, 1}:
+		return errorEndOfObject
+	default:
+		return s.fieldError(offset, e, fmt.Errorf("Unexpected object: %s in NodeEffect", encodings[e]))
+	}
+}
+
+func unmarshalNodeEffectArray(r Reader, s *decodeState) ([]NodeEffect, bool, error) {
+	var array []NodeEffect
+	for i := 0; ; i++ {
+		offset := s.offset()
+		e, err := readFieldHeader(r)
+		if err != nil {
+			return array, false, nil
+		}
+		if e == (enc{15, 1}) {
+			return array, true, nil
+		}
+		var element NodeEffect
+		s.pushIndex(i)
+		err = element.unmarshalElement(r, offset, e, s)
+		s.pop()
+		if err != errorEndOfObject {
+			return array, false, err
+		}
+		array = append(array, element)
+	}
+}
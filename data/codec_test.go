@@ -1,9 +1,17 @@
 package data
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
 
 	internal "github.com/ffddw/ripple/testing"
+	"github.com/juju/testing/checkers"
 	. "gopkg.in/check.v1"
 )
 
@@ -93,3 +101,169 @@ func (s *CodecSuite) TestParseMetaData(c *C) {
 		}
 	}
 }
+
+func (s *CodecSuite) TestUnknownFields(c *C) {
+	payment := internal.Transactions[0].Encoded
+	// NetworkID, an unknown UInt32, an unknown object and an unknown array
+	test := internal.TestData{
+		Description: "Payment with unknown fields",
+		Encoded: payment[:6] + "2100000400" + payment[6:36] + "203C0000002A" + payment[36:] +
+			"E01E2400000001E1" + "F028E01E2400000001E1F1",
+	}
+	tx, err := ReadTransaction(test.Reader())
+	msg := dump(test, tx)
+	c.Assert(err, IsNil, msg)
+	unknown := tx.GetBase().Unknown
+	c.Assert(unknown, HasLen, 4, msg)
+	c.Assert(string(b2h(unknown[reverseEncodings["NetworkID"]])), Equals, "00000400", msg)
+	hash, raw, err := Raw(tx)
+	c.Assert(err, IsNil, msg)
+	c.Assert(string(b2h(raw)), Equals, test.Encoded, msg)
+	expected, err := hashValues([]interface{}{HP_TRANSACTION_ID, test.Bytes()})
+	c.Assert(err, IsNil, msg)
+	c.Assert(hash, Equals, expected, msg)
+}
+
+func (s *CodecSuite) TestDecodeErrors(c *C) {
+	test := txHashTests[0]
+	tx, err := hex.DecodeString(test.Tx)
+	c.Assert(err, IsNil)
+	meta, err := hex.DecodeString(test.Meta)
+	c.Assert(err, IsNil)
+	// Truncate the metadata inside the Balance of the first created node
+	meta = meta[:54]
+	_, err = ReadTransactionAndMetadata(bytes.NewReader(tx), bytes.NewReader(meta), Hash256{}, 0)
+	var decodeErr *DecodeError
+	c.Assert(errors.As(fmt.Errorf("wrapped: %w", err), &decodeErr), Equals, true)
+	c.Check(decodeErr.Offset, Equals, 50)
+	c.Check(decodeErr.FieldPath(), Equals, "AffectedNodes[0].CreatedNode.NewFields.Balance")
+	c.Check(decodeErr.Type, Equals, ST_AMOUNT)
+	c.Check(decodeErr.Field, Equals, uint8(2))
+	c.Check(HexDump(meta, err), Matches, `(?s).*00000030  00 01 62 40 00 00\n {16}\^\^ AffectedNodes.*`)
+
+	for _, test := range internal.BadNodes {
+		nodeid, err := NewHash256(test.NodeId())
+		c.Assert(err, IsNil)
+		_, err = ReadPrefix(test.Reader(), *nodeid)
+		c.Assert(errors.As(err, &decodeErr), Equals, true, Commentf(test.Description))
+		c.Check(decodeErr.Path, Not(HasLen), 0, Commentf(test.Description))
+	}
+}
+
+// withCodec runs f with the generated codec enabled or disabled
+func withCodec(generated bool, f func()) {
+	defer func(previous bool) { useGeneratedCodec = previous }(useGeneratedCodec)
+	useGeneratedCodec = generated
+	f()
+}
+
+// checkCodecs decodes b with both codecs, checks the results match and
+// then checks both codecs encode the result to b
+func checkCodecs(c *C, description string, b []byte, nodeId Hash256) {
+	var reflected, generated Storer
+	var err error
+	withCodec(false, func() { reflected, err = ReadPrefix(bytes.NewReader(b), nodeId) })
+	c.Assert(err, IsNil, Commentf(description))
+	withCodec(true, func() { generated, err = ReadPrefix(bytes.NewReader(b), nodeId) })
+	c.Assert(err, IsNil, Commentf(description))
+	c.Assert(generated, checkers.DeepEquals, reflected, Commentf(description))
+	for _, useGenerated := range []bool{false, true} {
+		withCodec(useGenerated, func() {
+			_, value, err := Node(generated)
+			c.Assert(err, IsNil, Commentf(description))
+			c.Assert(string(b2h(value))[16:], Equals, string(b2h(b))[16:], Commentf("%s generated: %t", description, useGenerated))
+		})
+	}
+}
+
+func (s *CodecSuite) TestGeneratedCodec(c *C) {
+	for _, test := range internal.Nodes {
+		nodeId, err := NewHash256(test.NodeId())
+		c.Assert(err, IsNil)
+		checkCodecs(c, test.Description, test.Bytes(), *nodeId)
+	}
+	files, err := filepath.Glob("testdata/transaction_*.json")
+	c.Assert(err, IsNil)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		c.Assert(err, IsNil)
+		var txm TransactionWithMetaData
+		c.Assert(json.Unmarshal(b, &txm), IsNil)
+		nodeId, value, err := Node(&txm)
+		c.Assert(err, IsNil, Commentf(f))
+		checkCodecs(c, f, value, nodeId)
+	}
+	amendments := &Amendments{
+		leBase: leBase{LedgerEntryType: AMENDMENTS},
+		Majorities: []Majority{
+			{Amendment: &Hash256{1}, CloseTime: new(uint32)},
+			{Amendment: &Hash256{2}},
+		},
+	}
+	nodeId, value, err := Node(amendments)
+	c.Assert(err, IsNil)
+	checkCodecs(c, "Amendments with Majorities", value, nodeId)
+}
+
+// benchmarkTransactionNode writes a transaction from the websockets stream
+// fixture as a node and reads it back, as when hashing and storing it
+func benchmarkTransactionNode(b *testing.B, generated bool) {
+	bites, err := os.ReadFile("../websockets/testdata/transactions_stream.json")
+	if err != nil {
+		b.Fatal(err)
+	}
+	var msg struct {
+		Transaction TransactionWithMetaData
+		Meta        MetaData
+	}
+	if err := json.Unmarshal(bites, &msg); err != nil {
+		b.Fatal(err)
+	}
+	txm := &msg.Transaction
+	txm.MetaData = msg.Meta
+	withCodec(generated, func() {
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			nodeId, node, err := Node(txm)
+			if err != nil {
+				b.Fatal(err)
+			}
+			if _, err := ReadPrefix(bytes.NewReader(node), nodeId); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkTransactionNodeReflect(b *testing.B)   { benchmarkTransactionNode(b, false) }
+func BenchmarkTransactionNodeGenerated(b *testing.B) { benchmarkTransactionNode(b, true) }
+
+func (s *CodecSuite) TestNodeStream(c *C) {
+	var nodes []Storer
+	for _, test := range internal.Nodes {
+		nodeId, err := NewHash256(test.NodeId())
+		c.Assert(err, IsNil)
+		node, err := ReadPrefix(test.Reader(), *nodeId)
+		c.Assert(err, IsNil)
+		nodes = append(nodes, node)
+	}
+	var buf bytes.Buffer
+	w := NewNodeWriter(&buf)
+	for _, node := range nodes {
+		c.Assert(w.Write(node), IsNil)
+	}
+	c.Assert(w.Count(), Equals, len(nodes))
+	r := NewNodeReader(bytes.NewReader(buf.Bytes()))
+	var i int
+	c.Assert(r.Each(func(node Storer) error {
+		c.Check(node.NodeId().String(), Equals, nodes[i].NodeId().String(), Commentf(internal.Nodes[i].Description))
+		c.Check(node, checkers.DeepEquals, nodes[i], Commentf(internal.Nodes[i].Description))
+		i++
+		return nil
+	}), IsNil)
+	c.Assert(r.Count(), Equals, len(nodes))
+
+	truncated := NewNodeReader(bytes.NewReader(buf.Bytes()[:buf.Len()-10]))
+	err := truncated.Each(func(Storer) error { return nil })
+	c.Assert(err, ErrorMatches, fmt.Sprintf("Node %d .*: short value: unexpected EOF", len(nodes)-1))
+}
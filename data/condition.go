@@ -0,0 +1,209 @@
+package data
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+)
+
+// Crypto-conditions lock an escrow until a fulfillment is presented with the
+// EscrowFinish. Only the PREIMAGE-SHA-256 type is supported by rippled. Its
+// fulfillment is a preimage and its condition is the SHA-256 of that preimage
+// and the preimage's length, which is the cost. Both are DER encoded:
+//
+//	Fulfillment: A0 len 80 len preimage
+//	Condition:   A0 len 80 20 fingerprint 81 len cost
+
+// ConditionType is the type of a crypto-condition
+type ConditionType uint8
+
+const (
+	PreimageSha256 ConditionType = 0
+)
+
+const (
+	MaxPreimageLength = 128 // the largest preimage accepted by rippled
+	maxConditionCost  = MaxPreimageLength
+)
+
+// Condition is a parsed crypto-condition
+type Condition struct {
+	Type        ConditionType
+	Fingerprint Hash256
+	Cost        uint64
+}
+
+// Fulfillment is a parsed crypto-condition fulfillment
+type Fulfillment struct {
+	Type     ConditionType
+	Preimage []byte
+}
+
+// NewPreimageSha256 returns the PREIMAGE-SHA-256 fulfillment of preimage and
+// the condition it fulfills, ready for an EscrowFinish and EscrowCreate
+func NewPreimageSha256(preimage []byte) (condition, fulfillment VariableLength, err error) {
+	if len(preimage) > MaxPreimageLength {
+		return nil, nil, fmt.Errorf("Preimage too long: %d", len(preimage))
+	}
+	f := &Fulfillment{Type: PreimageSha256, Preimage: preimage}
+	return f.Condition().Bytes(), f.Bytes(), nil
+}
+
+// Condition returns the condition which f fulfills
+func (f *Fulfillment) Condition() *Condition {
+	return &Condition{
+		Type:        f.Type,
+		Fingerprint: sha256.Sum256(f.Preimage),
+		Cost:        uint64(len(f.Preimage)),
+	}
+}
+
+// Bytes returns the DER encoding of f
+func (f *Fulfillment) Bytes() []byte {
+	return derEncode(0xA0|byte(f.Type), derEncode(0x80, f.Preimage))
+}
+
+// Bytes returns the DER encoding of c
+func (c *Condition) Bytes() []byte {
+	var cost []byte
+	for n := c.Cost; n > 0; n >>= 8 {
+		cost = append([]byte{byte(n)}, cost...)
+	}
+	if len(cost) == 0 || cost[0]&0x80 != 0 {
+		cost = append([]byte{0}, cost...)
+	}
+	body := append(derEncode(0x80, c.Fingerprint[:]), derEncode(0x81, cost)...)
+	return derEncode(0xA0|byte(c.Type), body)
+}
+
+// ParseCondition decodes a PREIMAGE-SHA-256 condition
+func ParseCondition(b []byte) (*Condition, error) {
+	body, err := derDecodeAll(b, 0xA0|byte(PreimageSha256), "condition")
+	if err != nil {
+		return nil, err
+	}
+	fingerprint, rest, err := derDecode(body, 0x80)
+	if err != nil {
+		return nil, fmt.Errorf("Bad condition fingerprint: %s", err)
+	}
+	if len(fingerprint) != len(Hash256{}) {
+		return nil, fmt.Errorf("Bad condition fingerprint length: %d", len(fingerprint))
+	}
+	cost, rest, err := derDecode(rest, 0x81)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("Bad condition cost: %s", err)
+	case len(rest) != 0:
+		return nil, fmt.Errorf("Unexpected data after condition cost")
+	case len(cost) == 0 || len(cost) > 9 || (len(cost) > 1 && cost[0] == 0 && cost[1]&0x80 == 0) || cost[0]&0x80 != 0:
+		return nil, fmt.Errorf("Bad condition cost encoding")
+	}
+	c := &Condition{Type: PreimageSha256}
+	copy(c.Fingerprint[:], fingerprint)
+	for _, b := range cost {
+		c.Cost = c.Cost<<8 | uint64(b)
+	}
+	if c.Cost > maxConditionCost {
+		return nil, fmt.Errorf("Condition cost too high: %d", c.Cost)
+	}
+	return c, nil
+}
+
+// ParseFulfillment decodes a PREIMAGE-SHA-256 fulfillment
+func ParseFulfillment(b []byte) (*Fulfillment, error) {
+	body, err := derDecodeAll(b, 0xA0|byte(PreimageSha256), "fulfillment")
+	if err != nil {
+		return nil, err
+	}
+	preimage, rest, err := derDecode(body, 0x80)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("Bad fulfillment preimage: %s", err)
+	case len(rest) != 0:
+		return nil, fmt.Errorf("Unexpected data after fulfillment preimage")
+	case len(preimage) > MaxPreimageLength:
+		return nil, fmt.Errorf("Preimage too long: %d", len(preimage))
+	}
+	return &Fulfillment{Type: PreimageSha256, Preimage: preimage}, nil
+}
+
+// VerifyFulfillment checks that the encoded fulfillment fulfills the encoded
+// condition
+func VerifyFulfillment(condition, fulfillment []byte) error {
+	c, err := ParseCondition(condition)
+	if err != nil {
+		return err
+	}
+	f, err := ParseFulfillment(fulfillment)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(f.Condition().Bytes(), c.Bytes()) {
+		return fmt.Errorf("Fulfillment does not match condition")
+	}
+	return nil
+}
+
+// EscrowFinishFee returns the fee in drops of an EscrowFinish with the
+// encoded fulfillment. rippled charges the base fee plus a further 32 base
+// fees and one base fee per 16 bytes of fulfillment.
+func EscrowFinishFee(baseFee uint64, fulfillment []byte) uint64 {
+	if len(fulfillment) == 0 {
+		return baseFee
+	}
+	return baseFee * (33 + uint64(len(fulfillment))/16)
+}
+
+func derEncode(tag byte, content []byte) []byte {
+	b := []byte{tag}
+	switch n := len(content); {
+	case n < 0x80:
+		b = append(b, byte(n))
+	case n <= 0xFF:
+		b = append(b, 0x81, byte(n))
+	default:
+		b = append(b, 0x82, byte(n>>8), byte(n))
+	}
+	return append(b, content...)
+}
+
+// derDecode returns the content of the element with tag at the start of b
+// and the remainder of b
+func derDecode(b []byte, tag byte) ([]byte, []byte, error) {
+	if len(b) < 2 {
+		return nil, nil, fmt.Errorf("Truncated DER")
+	}
+	if b[0] != tag {
+		return nil, nil, fmt.Errorf("Unexpected DER tag: %02X", b[0])
+	}
+	n, b := int(b[1]), b[2:]
+	switch {
+	case n < 0x80:
+	case n == 0x81 && len(b) >= 1 && b[0] >= 0x80:
+		n, b = int(b[0]), b[1:]
+	case n == 0x82 && len(b) >= 2 && b[0] != 0:
+		n, b = int(b[0])<<8|int(b[1]), b[2:]
+	default:
+		return nil, nil, fmt.Errorf("Bad DER length")
+	}
+	if len(b) < n {
+		return nil, nil, fmt.Errorf("Truncated DER")
+	}
+	return b[:n], b[n:], nil
+}
+
+// derDecodeAll decodes an element of a known crypto-condition type which
+// must fill b
+func derDecodeAll(b []byte, tag byte, what string) ([]byte, error) {
+	if len(b) > 0 && b[0] != tag && b[0]&0xE0 == 0xA0 {
+		return nil, fmt.Errorf("Unsupported %s type: %d", what, b[0]&0x1F)
+	}
+	body, rest, err := derDecode(b, tag)
+	switch {
+	case err != nil:
+		return nil, fmt.Errorf("Bad %s: %s", what, err)
+	case len(rest) != 0:
+		return nil, fmt.Errorf("Unexpected data after %s", what)
+	}
+	return body, nil
+}
@@ -0,0 +1,86 @@
+package data
+
+import (
+	"bytes"
+	"encoding/hex"
+	"regexp"
+	"testing"
+)
+
+func TestPreimageSha256(t *testing.T) {
+	// The empty preimage, from the crypto-conditions specification
+	condition, fulfillment, err := NewPreimageSha256(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s := hex.EncodeToString(condition); s != "a0258020e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855810100" {
+		t.Fatalf("Bad condition: %s", s)
+	}
+	if s := hex.EncodeToString(fulfillment); s != "a0028000" {
+		t.Fatalf("Bad fulfillment: %s", s)
+	}
+	if err := VerifyFulfillment(condition, fulfillment); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, n := range []int{1, 32, 127, 128} {
+		preimage := bytes.Repeat([]byte{byte(n)}, n)
+		condition, fulfillment, err := NewPreimageSha256(preimage)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := VerifyFulfillment(condition, fulfillment); err != nil {
+			t.Fatalf("%d: %s", n, err)
+		}
+		c, err := ParseCondition(condition)
+		if err != nil {
+			t.Fatal(err)
+		}
+		f, err := ParseFulfillment(fulfillment)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Cost != uint64(n) || !bytes.Equal(f.Preimage, preimage) {
+			t.Fatalf("%d: Bad round trip: %d %X", n, c.Cost, f.Preimage)
+		}
+	}
+	if _, _, err := NewPreimageSha256(make([]byte, 129)); err == nil {
+		t.Fatal("Long preimage was accepted")
+	}
+
+	other, wrong, _ := NewPreimageSha256([]byte("wrong"))
+	for _, test := range []struct {
+		condition, fulfillment string
+		err                    string
+	}{
+		{hex.EncodeToString(condition), hex.EncodeToString(wrong), "Fulfillment does not match condition"},
+		{hex.EncodeToString(other), hex.EncodeToString(fulfillment), "Fulfillment does not match condition"},
+		{"a0258020e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b85581010000", "a0028000", "Unexpected data after condition"},
+		{"a0258020e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855810200", "a0028000", "Bad condition"},
+		{"a2258020e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855810100", "a0028000", "Unsupported condition type: 2"},
+		{hex.EncodeToString(condition), "a00280", "Bad fulfillment: Truncated DER"},
+		{hex.EncodeToString(condition), "a0038000ff", "Unexpected data after fulfillment preimage"},
+	} {
+		condition, _ := hex.DecodeString(test.condition)
+		fulfillment, _ := hex.DecodeString(test.fulfillment)
+		if err := VerifyFulfillment(condition, fulfillment); err == nil || !regexp.MustCompile(test.err).MatchString(err.Error()) {
+			t.Fatalf("Expected error: %s got: %v", test.err, err)
+		}
+	}
+}
+
+func TestEscrowFinishFee(t *testing.T) {
+	_, fulfillment, _ := NewPreimageSha256(make([]byte, 32))
+	for _, test := range []struct {
+		fulfillment []byte
+		fee         uint64
+	}{
+		{nil, 10},
+		{fulfillment, 350},
+		{make([]byte, 16), 340},
+	} {
+		if fee := EscrowFinishFee(10, test.fulfillment); fee != test.fee {
+			t.Fatalf("Expected fee %d got: %d", test.fee, fee)
+		}
+	}
+}
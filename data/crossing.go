@@ -0,0 +1,326 @@
+package data
+
+import (
+	"fmt"
+	"sort"
+)
+
+// TransferRates are the TransferRates of issuers, in billionths, where
+// 1000000000 or an absent issuer is no fee
+type TransferRates map[Account]uint32
+
+// fee returns the multiplier for a transfer of an asset of issuer between
+// from and to, neither of whom pays a fee when they are the issuer
+func (r TransferRates) fee(issuer, from, to Account) (*Value, error) {
+	rate := r[issuer]
+	if rate == 0 || rate == 1000000000 || from == issuer || to == issuer {
+		return NewNonNativeValue(1, 0)
+	}
+	return NewNonNativeValue(int64(rate), -9)
+}
+
+// CrossedOffer is an Offer which a Crossing exchanged with
+type CrossedOffer struct {
+	Offer *OrderBookOffer
+	Paid  *Amount // By the taker to the owner, from the Offer's TakerPays
+	Got   *Amount // By the taker from the owner, from the Offer's TakerGets
+	// Deleted is set when the Offer or the funds of its owner are used up
+	Deleted bool
+}
+
+// Crossing is what an OfferCreate would do to an order book
+type Crossing struct {
+	Crossed []CrossedOffer
+	// Removed are the Offers which were passed over and deleted because
+	// their owners had no funds, or because the taker owned them
+	Removed []*OrderBookOffer
+	Paid    *Amount // By the taker, including transfer fees
+	PaidNet *Amount // By the taker, excluding transfer fees
+	Got     *Amount // By the taker
+	// Placed is the rest of the OfferCreate which would remain in the book
+	Placed *Offer
+	// Killed is set when TxFillOrKill could not be filled, in which case
+	// nothing is crossed, removed or placed
+	Killed bool
+}
+
+func (c Crossing) String() string {
+	placed := "nothing"
+	if c.Placed != nil {
+		placed = fmt.Sprintf("%s for %s", c.Placed.TakerGets, c.Placed.TakerPays)
+	}
+	return fmt.Sprintf("Paid: %s Got: %s Crossed: %d Removed: %d Placed: %s Killed: %t", c.Paid, c.Got, len(c.Crossed), len(c.Removed), placed, c.Killed)
+}
+
+// Simulate crosses tx with book, which holds the offers which give the
+// TakerPays of tx in exchange for its TakerGets, as returned by book_offers.
+// The funds of each owner are read from the OwnerFunds of the first of its
+// offers. Offers are taken in order of Ratio, best first, for as long as
+// their Ratio is no more than TakerGets/TakerPays of tx, or is strictly less
+// for TxPassive. Without TxSell the crossing stops once TakerPays has been
+// got, and with it once TakerGets has been paid.
+//
+// The taker pays the transfer fee on the asset it pays, and each owner on
+// the asset it delivers, unless either party is the issuer. As in rippled,
+// the taker may spend TakerGets plus its transfer fee, and what remains to
+// be placed is reckoned without fees. Expiration and the funds of the taker
+// are not considered.
+func (tx *OfferCreate) Simulate(book []OrderBookOffer, rates TransferRates) (*Crossing, error) {
+	if tx.TakerPays.IsZero() || tx.TakerPays.IsNegative() || tx.TakerGets.IsZero() || tx.TakerGets.IsNegative() {
+		return nil, fmt.Errorf("OfferCreate must have positive TakerPays and TakerGets")
+	}
+	var flags TransactionFlag
+	if tx.Flags != nil {
+		flags = *tx.Flags
+	}
+	var (
+		sell    = flags&TxSell != 0
+		passive = flags&TxPassive != 0
+		limit   = tx.TakerGets.Ratio(tx.TakerPays)
+		offers  = make([]*OrderBookOffer, len(book))
+		funds   = make(map[Account]*Value)
+	)
+	for i := range book {
+		o := &book[i]
+		if o.Account == nil || o.TakerPays == nil || o.TakerGets == nil {
+			return nil, fmt.Errorf("Offer %d is incomplete", i)
+		}
+		if *o.TakerPays.Asset() != *tx.TakerGets.Asset() || *o.TakerGets.Asset() != *tx.TakerPays.Asset() {
+			return nil, fmt.Errorf("Offer %d of %s is not in the book for %s", i, o.Account, tx.TakerPays.Asset())
+		}
+		if _, ok := funds[*o.Account]; !ok {
+			funds[*o.Account] = o.OwnerFunds.Clone()
+		}
+		offers[i] = o
+	}
+	sort.SliceStable(offers, func(i, j int) bool {
+		return offers[i].Ratio().Less(*offers[j].Ratio())
+	})
+	rate, err := rates.fee(tx.TakerGets.Issuer, tx.Account, zeroAccount)
+	if err != nil {
+		return nil, err
+	}
+	sendMax, err := multiply(tx.TakerGets.Value, rate)
+	if err != nil {
+		return nil, err
+	}
+	c := &Crossing{
+		Paid:    tx.TakerGets.ZeroClone(),
+		PaidNet: tx.TakerGets.ZeroClone(),
+		Got:     tx.TakerPays.ZeroClone(),
+	}
+	for _, o := range offers {
+		if c.filled(tx, sendMax, sell) {
+			break
+		}
+		if cmp := o.Ratio().Compare(*limit); cmp > 0 || (passive && cmp == 0) {
+			break
+		}
+		if *o.Account == tx.Account {
+			c.Removed = append(c.Removed, o)
+			continue
+		}
+		crossed, err := c.cross(tx, o, sendMax, sell, funds, rates)
+		if err != nil {
+			return nil, err
+		}
+		if crossed == nil {
+			c.Removed = append(c.Removed, o)
+			continue
+		}
+		c.Crossed = append(c.Crossed, *crossed)
+	}
+	switch {
+	case flags&TxFillOrKill != 0 && !c.filled(tx, sendMax, sell):
+		return &Crossing{
+			Paid:    tx.TakerGets.ZeroClone(),
+			PaidNet: tx.TakerGets.ZeroClone(),
+			Got:     tx.TakerPays.ZeroClone(),
+			Killed:  true,
+		}, nil
+	case flags&TxImmediateOrCancel != 0:
+		return c, nil
+	}
+	return c, c.place(tx, sell, flags)
+}
+
+// filled returns whether the taker has got all of TakerPays, or with TxSell
+// spent all of sendMax
+func (c *Crossing) filled(tx *OfferCreate, sendMax *Value, sell bool) bool {
+	if sell {
+		return c.Paid.Compare(*sendMax) >= 0
+	}
+	return c.Got.Compare(*tx.TakerPays.Value) >= 0
+}
+
+// cross exchanges as much as possible with o, or returns nil when its owner
+// has no funds
+func (c *Crossing) cross(tx *OfferCreate, o *OrderBookOffer, sendMax *Value, sell bool, funds map[Account]*Value, rates TransferRates) (*CrossedOffer, error) {
+	owner := *o.Account
+	feeOut, err := rates.fee(o.TakerGets.Issuer, owner, tx.Account)
+	if err != nil {
+		return nil, err
+	}
+	feeIn, err := rates.fee(o.TakerPays.Issuer, tx.Account, owner)
+	if err != nil {
+		return nil, err
+	}
+	// The most which the owner can deliver
+	got := o.TakerGets.Clone()
+	issuer := !o.TakerGets.IsNative() && o.TakerGets.Issuer == owner
+	if !issuer {
+		deliverable, err := divide(funds[owner], feeOut)
+		if err != nil {
+			return nil, err
+		}
+		if deliverable.Less(*got.Value) {
+			got.Value = deliverable
+		}
+	}
+	if got.IsZero() || got.IsNegative() {
+		return nil, nil
+	}
+	// The most which the taker still wants
+	if !sell {
+		wanted, err := tx.TakerPays.Value.Subtract(*c.Got.Value)
+		if err != nil {
+			return nil, err
+		}
+		if wanted.Less(*got.Value) {
+			got.Value = wanted
+		}
+	}
+	paid, err := proportion(o.TakerPays, got, o.TakerGets)
+	if err != nil {
+		return nil, err
+	}
+	cost, err := multiply(paid.Value, feeIn)
+	if err != nil {
+		return nil, err
+	}
+	// The most which the taker can still pay, including fees
+	budget, err := sendMax.Subtract(*c.Paid.Value)
+	if err != nil {
+		return nil, err
+	}
+	if budget.Less(*cost) {
+		cost = budget
+		if paid.Value, err = divide(budget, feeIn); err != nil {
+			return nil, err
+		}
+		if got, err = proportion(o.TakerGets, paid, o.TakerPays); err != nil {
+			return nil, err
+		}
+	}
+	if c.Paid.Value, err = c.Paid.Value.Add(*cost); err != nil {
+		return nil, err
+	}
+	if c.PaidNet.Value, err = c.PaidNet.Value.Add(*paid.Value); err != nil {
+		return nil, err
+	}
+	if c.Got.Value, err = c.Got.Value.Add(*got.Value); err != nil {
+		return nil, err
+	}
+	crossed := &CrossedOffer{Offer: o, Paid: paid, Got: got}
+	if !got.Less(*o.TakerGets.Value) {
+		crossed.Deleted = true
+	}
+	if !issuer {
+		spent, err := multiply(got.Value, feeOut)
+		if err != nil {
+			return nil, err
+		}
+		if funds[owner], err = funds[owner].Subtract(*spent); err != nil {
+			return nil, err
+		}
+		if !funds[owner].IsNegative() && !funds[owner].IsZero() {
+			return crossed, nil
+		}
+		crossed.Deleted = true
+	}
+	return crossed, nil
+}
+
+// place sets the rest of tx which would be placed in the book, keeping the
+// ratio of tx
+func (c *Crossing) place(tx *OfferCreate, sell bool, flags TransactionFlag) error {
+	var (
+		pays, gets *Amount
+		err        error
+	)
+	if sell {
+		if gets, err = tx.TakerGets.Subtract(c.PaidNet); err != nil {
+			return err
+		}
+		pays, err = proportion(&tx.TakerPays, gets, &tx.TakerGets)
+	} else {
+		if pays, err = tx.TakerPays.Subtract(c.Got); err != nil {
+			return err
+		}
+		gets, err = proportion(&tx.TakerGets, pays, &tx.TakerPays)
+	}
+	if err != nil {
+		return err
+	}
+	if pays.IsZero() || pays.IsNegative() || gets.IsZero() || gets.IsNegative() {
+		return nil
+	}
+	var leFlags LedgerEntryFlag
+	if flags&TxPassive != 0 {
+		leFlags |= LsPassive
+	}
+	if flags&TxSell != 0 {
+		leFlags |= LsSell
+	}
+	account, sequence := tx.Account, tx.Sequence
+	c.Placed = &Offer{
+		leBase:    leBase{LedgerEntryType: OFFER},
+		Flags:     &leFlags,
+		Account:   &account,
+		Sequence:  &sequence,
+		TakerPays: pays,
+		TakerGets: gets,
+	}
+	return nil
+}
+
+// proportion returns the part of a which part is of whole
+func proportion(a, part, whole *Amount) (*Amount, error) {
+	fraction, err := part.Value.Ratio(*whole.Value)
+	if err != nil {
+		return nil, err
+	}
+	v, err := multiply(a.Value, fraction)
+	if err != nil {
+		return nil, err
+	}
+	return newAmount(v, a.Currency, a.Issuer), nil
+}
+
+func multiply(v, factor *Value) (*Value, error) {
+	if v.IsNative() {
+		return nativeScale(v, factor, Value.Multiply)
+	}
+	return v.Multiply(*factor)
+}
+
+func divide(v, factor *Value) (*Value, error) {
+	if v.IsNative() {
+		return nativeScale(v, factor, Value.Divide)
+	}
+	return v.Divide(*factor)
+}
+
+// nativeScale scales drips as a non-native Value for precision, and
+// truncates the result to whole drips
+func nativeScale(v, factor *Value, op func(Value, Value) (*Value, error)) (*Value, error) {
+	nonNative, err := v.NonNative()
+	if err != nil {
+		return nil, err
+	}
+	scaled, err := op(*nonNative, *factor)
+	if err != nil {
+		return nil, err
+	}
+	return scaled.Native()
+}
@@ -0,0 +1,134 @@
+package data
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const (
+	crossingTaker  = "r3kmLJN5D28dHuH8vZNUZpMC43pEHpaocV"
+	crossingIssuer = "rnziParaNb8nsU4aruQdwYE3j5jUcqjzFm"
+)
+
+// Offers to sell USD for XRP, as returned by book_offers
+const crossingBook = `[
+	{"Account": "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2", "Sequence": 1, "owner_funds": "20",
+		"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "50"}, "TakerPays": "500000000"},
+	{"Account": "rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj", "Sequence": 1, "owner_funds": "30",
+		"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "30"}, "TakerPays": "270000000"},
+	{"Account": "TAKER", "Sequence": 1, "owner_funds": "100",
+		"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "10"}, "TakerPays": "95000000"},
+	{"Account": "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", "Sequence": 1, "owner_funds": "1000",
+		"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "40"}, "TakerPays": "480000000"}
+]`
+
+func readBook(t *testing.T, s string) []OrderBookOffer {
+	t.Helper()
+	s = strings.NewReplacer("TAKER", crossingTaker, "ISSUER", crossingIssuer).Replace(s)
+	var book []OrderBookOffer
+	if err := json.Unmarshal([]byte(s), &book); err != nil {
+		t.Fatal(err)
+	}
+	return book
+}
+
+func newOfferCreate(t *testing.T, pays, gets string, flags TransactionFlag) *OfferCreate {
+	t.Helper()
+	taker, err := NewAccountFromAddress(crossingTaker)
+	if err != nil {
+		t.Fatal(err)
+	}
+	takerPays, err := NewAmount(strings.Replace(pays, "ISSUER", crossingIssuer, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	takerGets, err := NewAmount(strings.Replace(gets, "ISSUER", crossingIssuer, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &OfferCreate{
+		TxBase:    TxBase{TransactionType: OFFER_CREATE, Account: *taker, Sequence: 2, Flags: &flags},
+		TakerPays: *takerPays,
+		TakerGets: *takerGets,
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	book := readBook(t, crossingBook)
+	for _, test := range []struct {
+		name     string
+		flags    TransactionFlag
+		rates    TransferRates
+		expected string
+	}{
+		{"Default", 0, nil, "Paid: 470/XRP Got: 50/USD/ISSUER Crossed: 2 Removed: 1 Placed: 500/XRP for 50/USD/ISSUER Killed: false"},
+		{"ImmediateOrCancel", TxImmediateOrCancel, nil, "Paid: 470/XRP Got: 50/USD/ISSUER Crossed: 2 Removed: 1 Placed: nothing Killed: false"},
+		{"FillOrKill", TxFillOrKill, nil, "Paid: 0/XRP Got: 0/USD/ISSUER Crossed: 0 Removed: 0 Placed: nothing Killed: true"},
+		{"Passive", TxPassive, nil, "Paid: 270/XRP Got: 30/USD/ISSUER Crossed: 1 Removed: 1 Placed: 700/XRP for 70/USD/ISSUER Killed: false"},
+		{"Sell", TxSell, nil, "Paid: 470/XRP Got: 50/USD/ISSUER Crossed: 2 Removed: 1 Placed: 530/XRP for 53/USD/ISSUER Killed: false"},
+		{"TransferRate", 0, TransferRates{book[0].TakerGets.Issuer: 1250000000}, "Paid: 376/XRP Got: 40/USD/ISSUER Crossed: 2 Removed: 1 Placed: 600/XRP for 60/USD/ISSUER Killed: false"},
+	} {
+		crossing, err := newOfferCreate(t, "100/USD/ISSUER", "1000000000", test.flags).Simulate(book, test.rates)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		expected := strings.Replace(test.expected, "ISSUER", crossingIssuer, -1)
+		if crossing.String() != expected {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", test.name, expected, crossing)
+		}
+	}
+
+	// The offer of the owner with too few funds is deleted
+	crossing, err := newOfferCreate(t, "100/USD/ISSUER", "1000000000", 0).Simulate(book, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, crossed := range crossing.Crossed {
+		if !crossed.Deleted {
+			t.Errorf("Expected %s to be deleted", crossed.Offer.Account)
+		}
+	}
+	if crossing.Crossed[1].Got.String() != "20/USD/"+crossingIssuer {
+		t.Errorf("Expected 20/USD from the underfunded offer got %s", crossing.Crossed[1].Got)
+	}
+
+	if _, err := newOfferCreate(t, "100/EUR/ISSUER", "1000000000", 0).Simulate(book, nil); err == nil {
+		t.Error("Expected an error for an offer from another book")
+	}
+}
+
+// Offers to sell XRP for USD, so that the taker pays the transfer fee
+const crossingReversedBook = `[
+	{"Account": "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2", "Sequence": 1, "owner_funds": "1000000000",
+		"TakerGets": "200000000", "TakerPays": {"currency": "USD", "issuer": "ISSUER", "value": "20"}},
+	{"Account": "rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj", "Sequence": 1, "owner_funds": "1000000000",
+		"TakerGets": "180000000", "TakerPays": {"currency": "USD", "issuer": "ISSUER", "value": "20"}}
+]`
+
+func TestSimulateTakerFee(t *testing.T) {
+	book := readBook(t, crossingReversedBook)
+	rates := TransferRates{book[0].TakerPays.Issuer: 1250000000}
+	for _, test := range []struct {
+		name       string
+		pays, gets string
+		flags      TransactionFlag
+		expected   string
+		net        string
+	}{
+		{"Buy", "200000000", "20/USD/ISSUER", 0, "Paid: 25/USD/ISSUER Got: 200/XRP Crossed: 1 Removed: 0 Placed: nothing Killed: false", "20/USD/ISSUER"},
+		{"Sell", "400000000", "40/USD/ISSUER", TxSell, "Paid: 25/USD/ISSUER Got: 200/XRP Crossed: 1 Removed: 0 Placed: 20/USD/ISSUER for 200/XRP Killed: false", "20/USD/ISSUER"},
+	} {
+		crossing, err := newOfferCreate(t, test.pays, test.gets, test.flags).Simulate(book, rates)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		expected := strings.Replace(test.expected, "ISSUER", crossingIssuer, -1)
+		if crossing.String() != expected {
+			t.Errorf("%s: Expected:\n%s\nGot:\n%s", test.name, expected, crossing)
+		}
+		if net := strings.Replace(test.net, "ISSUER", crossingIssuer, -1); crossing.PaidNet.String() != net {
+			t.Errorf("%s: Expected %s paid without fees got %s", test.name, net, crossing.PaidNet)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DecodeError describes where decoding of the binary format failed.
+type DecodeError struct {
+	Offset int      // Offset in bytes of the failing field from the start of the input
+	Path   []string // Enclosing fields, outermost first, ending with the failing field
+	Type   uint8    // Serialized type code of the failing field, if known
+	Field  uint8    // Field code of the failing field, if known
+	Err    error    // The underlying error
+}
+
+// FieldPath returns the path to the failing field in the form
+// AffectedNodes[3].ModifiedNode.FinalFields.Balance
+func (e *DecodeError) FieldPath() string {
+	var s strings.Builder
+	for i, name := range e.Path {
+		if i > 0 && !strings.HasPrefix(name, "[") {
+			s.WriteByte('.')
+		}
+		s.WriteString(name)
+	}
+	return s.String()
+}
+
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("Decode error at offset %d in %s (type: %d field: %d): %s", e.Offset, e.FieldPath(), e.Type, e.Field, e.Err)
+}
+
+func (e *DecodeError) Unwrap() error {
+	return e.Err
+}
+
+// HexDump returns a hex dump of b. If err is or wraps a DecodeError, the
+// failing field is marked and the field path is shown beneath it.
+func HexDump(b []byte, err error) string {
+	var decodeErr *DecodeError
+	offset := -1
+	if errors.As(err, &decodeErr) {
+		offset = decodeErr.Offset
+	}
+	var s strings.Builder
+	for line := 0; line < len(b); line += 16 {
+		end := line + 16
+		if end > len(b) {
+			end = len(b)
+		}
+		fmt.Fprintf(&s, "%08X  % X\n", line, b[line:end])
+		if offset >= line && offset < line+16 {
+			fmt.Fprintf(&s, "%s^^ %s\n", strings.Repeat(" ", 10+(offset-line)*3), decodeErr.FieldPath())
+		}
+	}
+	if offset >= len(b) {
+		fmt.Fprintf(&s, "%08X  ^^ %s\n", offset, decodeErr.FieldPath())
+	}
+	return s.String()
+}
+
+// decodeState tracks the position of the decoder for error reporting. All
+// reads pass through r, so the offset can be derived from its length.
+type decodeState struct {
+	r     Reader
+	start int
+	path  []string
+}
+
+func newDecodeState(r Reader) *decodeState {
+	return &decodeState{r: r, start: r.Len()}
+}
+
+func (s *decodeState) offset() int      { return s.start - s.r.Len() }
+func (s *decodeState) push(name string) { s.path = append(s.path, name) }
+func (s *decodeState) pop()             { s.path = s.path[:len(s.path)-1] }
+
+// wrap returns err as a DecodeError for the field starting at offset,
+// leaving nil, the end markers and existing DecodeErrors untouched.
+func (s *decodeState) wrap(offset int, e *enc, err error) error {
+	switch err.(type) {
+	case nil, *DecodeError:
+		return err
+	}
+	if err == errorEndOfObject || err == errorEndOfArray {
+		return err
+	}
+	decodeErr := &DecodeError{
+		Offset: offset,
+		Path:   append([]string(nil), s.path...),
+		Err:    err,
+	}
+	if e != nil {
+		decodeErr.Type, decodeErr.Field = e.typ, e.field
+	}
+	return decodeErr
+}
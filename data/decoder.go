@@ -8,31 +8,34 @@ import (
 
 // ReadWire parses types received via the peer network
 func ReadWire(r Reader, typ NodeType, ledgerSequence uint32, nodeId Hash256) (Hashable, error) {
+	s := newDecodeState(r)
 	version, err := readHashPrefix(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(0, nil, err)
 	}
 	switch version {
 	case HP_LEAF_NODE:
-		return ReadLedgerEntry(r, nodeId)
+		return readLedgerEntry(r, nodeId, s)
 	case HP_TRANSACTION_NODE:
-		return readTransactionWithMetadata(r, ledgerSequence, nodeId)
+		return readTransactionWithMetadata(r, ledgerSequence, nodeId, s)
 	case HP_INNER_NODE:
 		return readCompressedInnerNode(r, typ, nodeId)
 	default:
-		return nil, fmt.Errorf("Unknown hash prefix: %s", version.String())
+		return nil, s.wrap(0, nil, fmt.Errorf("Unknown hash prefix: %s", version.String()))
 	}
 }
 
 // ReadPrefix parses types received from the nodestore
 func ReadPrefix(r Reader, nodeId Hash256) (Storer, error) {
+	s := newDecodeState(r)
 	header, err := readHeader(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(0, nil, err)
 	}
+	offset := s.offset()
 	version, err := readHashPrefix(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(offset, nil, err)
 	}
 	switch {
 	case version == HP_INNER_NODE:
@@ -40,11 +43,11 @@ func ReadPrefix(r Reader, nodeId Hash256) (Storer, error) {
 	case header.NodeType == NT_LEDGER:
 		return ReadLedger(r, nodeId)
 	case header.NodeType == NT_TRANSACTION_NODE:
-		return readTransactionWithMetadata(r, header.LedgerSequence, nodeId)
+		return readTransactionWithMetadata(r, header.LedgerSequence, nodeId, s)
 	case header.NodeType == NT_ACCOUNT_NODE:
-		return ReadLedgerEntry(r, nodeId)
+		return readLedgerEntry(r, nodeId, s)
 	default:
-		return nil, fmt.Errorf("Unknown node type")
+		return nil, s.wrap(0, nil, fmt.Errorf("Unknown node type"))
 	}
 }
 
@@ -55,20 +58,26 @@ func ReadLedger(r Reader, nodeId Hash256) (*Ledger, error) {
 			CloseTime:       NewRippleTime(0),
 		},
 	}
-	values := []interface{}{
-		&ledger.LedgerSequence,
-		&ledger.TotalXRP,
-		&ledger.PreviousLedger,
-		&ledger.TransactionHash,
-		&ledger.StateHash,
-		ledger.ParentCloseTime,
-		ledger.CloseTime,
-		&ledger.CloseResolution,
-		&ledger.CloseFlags,
+	values := []struct {
+		name  string
+		value interface{}
+	}{
+		{"LedgerSequence", &ledger.LedgerSequence},
+		{"TotalXRP", &ledger.TotalXRP},
+		{"PreviousLedger", &ledger.PreviousLedger},
+		{"TransactionHash", &ledger.TransactionHash},
+		{"StateHash", &ledger.StateHash},
+		{"ParentCloseTime", ledger.ParentCloseTime},
+		{"CloseTime", ledger.CloseTime},
+		{"CloseResolution", &ledger.CloseResolution},
+		{"CloseFlags", &ledger.CloseFlags},
 	}
+	s := newDecodeState(r)
 	for _, v := range values {
-		if err := read(r, v); err != nil {
-			return nil, err
+		offset := s.offset()
+		if err := read(r, v.value); err != nil {
+			s.push(v.name)
+			return nil, s.wrap(offset, nil, err)
 		}
 	}
 	ledger.Hash = nodeId
@@ -78,20 +87,26 @@ func ReadLedger(r Reader, nodeId Hash256) (*Ledger, error) {
 func ReadValidation(r Reader) (*Validation, error) {
 	validation := new(Validation)
 	v := reflect.ValueOf(validation)
-	if err := readObject(r, &v); err != nil {
+	if err := readObject(r, &v, newDecodeState(r)); err != nil {
 		return nil, err
 	}
 	return validation, nil
 }
 
 func ReadTransaction(r Reader) (Transaction, error) {
-	txType, err := expectType(r, "TransactionType")
+	return readTransaction(r, newDecodeState(r))
+}
+
+func readTransaction(r Reader, s *decodeState) (Transaction, error) {
+	txType, err := expectType(r, "TransactionType", s)
 	if err != nil {
 		return nil, err
 	}
+	if int(txType) >= len(TxFactory) || TxFactory[txType] == nil {
+		return nil, s.wrap(s.offset(), nil, fmt.Errorf("Unknown TransactionType: %d", txType))
+	}
 	tx := TxFactory[txType]()
-	v := reflect.ValueOf(tx)
-	if err := readObject(r, &v); err != nil {
+	if err := decodeObject(r, tx, s); err != nil {
 		return nil, err
 	}
 	return tx, nil
@@ -108,8 +123,7 @@ func ReadTransactionAndMetadata(tx, meta Reader, hash Hash256, ledger uint32) (*
 		Transaction:    t,
 		LedgerSequence: ledger,
 	}
-	m := reflect.ValueOf(&txm.MetaData)
-	if err := readObject(meta, &m); err != nil {
+	if err := decodeObject(meta, &txm.MetaData, newDecodeState(meta)); err != nil {
 		return nil, err
 	}
 	*txm.GetHash() = hash
@@ -120,12 +134,13 @@ func ReadTransactionAndMetadata(tx, meta Reader, hash Hash256, ledger uint32) (*
 }
 
 // For internal use when reading Prefix format
-func readTransactionWithMetadata(r Reader, ledger uint32, nodeId Hash256) (*TransactionWithMetaData, error) {
+func readTransactionWithMetadata(r Reader, ledger uint32, nodeId Hash256, s *decodeState) (*TransactionWithMetaData, error) {
+	offset := s.offset()
 	br, err := NewVariableByteReader(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(offset, nil, err)
 	}
-	tx, err := ReadTransaction(br)
+	tx, err := readTransaction(br, s)
 	if err != nil {
 		return nil, err
 	}
@@ -134,17 +149,18 @@ func readTransactionWithMetadata(r Reader, ledger uint32, nodeId Hash256) (*Tran
 		LedgerSequence: ledger,
 		Id:             nodeId,
 	}
+	offset = s.offset()
 	br, err = NewVariableByteReader(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(offset, nil, err)
 	}
-	meta := reflect.ValueOf(&txm.MetaData)
-	if err := readObject(br, &meta); err != nil {
+	if err := decodeObject(br, &txm.MetaData, s); err != nil {
 		return nil, err
 	}
+	offset = s.offset()
 	hash, err := readHash(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(offset, nil, err)
 	}
 	copy(txm.GetHash()[:], hash.Bytes())
 	return txm, nil
@@ -174,21 +190,28 @@ func readCompressedInnerNode(r Reader, typ NodeType, nodeId Hash256) (*InnerNode
 }
 
 func ReadLedgerEntry(r Reader, nodeId Hash256) (LedgerEntry, error) {
-	leType, err := expectType(r, "LedgerEntryType")
+	return readLedgerEntry(r, nodeId, newDecodeState(r))
+}
+
+func readLedgerEntry(r Reader, nodeId Hash256, s *decodeState) (LedgerEntry, error) {
+	leType, err := expectType(r, "LedgerEntryType", s)
 	if err != nil {
 		return nil, err
 	}
+	if int(leType) >= len(LedgerEntryFactory) || LedgerEntryFactory[leType] == nil {
+		return nil, s.wrap(s.offset(), nil, fmt.Errorf("Unknown LedgerEntryType: %d", leType))
+	}
 	le := LedgerEntryFactory[leType]()
-	v := reflect.ValueOf(le)
 	// LedgerEntries have 32 bytes of index suffixed
 	// but don't have a variable bytes indicator
 	lr := LimitedByteReader(r, int64(r.Len()-32))
-	if err := readObject(lr, &v); err != nil {
+	if err := decodeObject(lr, le, s); err != nil {
 		return nil, err
 	}
+	offset := s.offset()
 	hash, err := readHash(r)
 	if err != nil {
-		return nil, err
+		return nil, s.wrap(offset, nil, err)
 	}
 	copy(le.GetHash()[:], hash.Bytes())
 	copy(le.NodeId()[:], nodeId.Bytes())
@@ -218,17 +241,20 @@ func readHash(r Reader) (*Hash256, error) {
 	}
 }
 
-func expectType(r Reader, expected string) (uint16, error) {
+func expectType(r Reader, expected string, s *decodeState) (uint16, error) {
+	offset := s.offset()
+	s.push(expected)
+	defer s.pop()
 	enc, err := readEncoding(r)
 	if err != nil {
-		return 0, err
+		return 0, s.wrap(offset, nil, err)
 	}
 	name := encodings[*enc]
 	if name != expected {
-		return 0, fmt.Errorf("Unexpected type: %s expected: %s", name, expected)
+		return 0, s.wrap(offset, enc, fmt.Errorf("Unexpected type: %s expected: %s", name, expected))
 	}
 	var typ uint16
-	return typ, read(r, &typ)
+	return typ, s.wrap(offset, enc, read(r, &typ))
 }
 
 var (
@@ -236,137 +262,170 @@ var (
 	errorEndOfArray  = errors.New("EndOfArray")
 )
 
-func readObject(r Reader, v *reflect.Value) error {
-	var err error
-	for enc, err := readEncoding(r); err == nil; enc, err = readEncoding(r) {
+func readObject(r Reader, v *reflect.Value, s *decodeState) error {
+	for {
+		offset := s.offset()
+		enc, err := readEncoding(r)
+		if err != nil {
+			return nil
+		}
 		name := encodings[*enc]
-		// fmt.Println(name, v, v.IsValid(), enc.typ, enc.field)
-		switch enc.typ {
-		case ST_ARRAY:
-			if name == "EndOfArray" {
-				return errorEndOfArray
-			}
-			array := getField(v, enc)
-		loop:
-			for {
-				child := reflect.New(array.Type().Elem()).Elem()
-				err := readObject(r, &child)
-				switch err {
-				case errorEndOfArray:
-					break loop
-				case errorEndOfObject:
-					array.Set(reflect.Append(*array, child))
-				default:
-					return err
-				}
+		s.push(name)
+		next, err := readField(r, v, enc, name, s)
+		err = s.wrap(offset, enc, err)
+		s.pop()
+		if !next {
+			return err
+		}
+	}
+}
+
+// readField reads the value for a single field into v. It returns true if
+// there are more fields to be read for the current object.
+func readField(r Reader, v *reflect.Value, enc *enc, name string, s *decodeState) (bool, error) {
+	// fmt.Println(name, v, v.IsValid(), enc.typ, enc.field)
+	switch enc.typ {
+	case ST_ARRAY:
+		if name == "EndOfArray" {
+			return false, errorEndOfArray
+		}
+		array := getField(v, enc)
+		if !array.IsValid() {
+			if ok, err := readUnknown(r, v, enc); ok {
+				return err == nil, err
 			}
-		case ST_OBJECT:
-			switch name {
-			case "EndOfObject":
-				return errorEndOfObject
-			case "PreviousFields", "NewFields", "FinalFields":
-				leType := LedgerEntryType(v.Elem().FieldByName("LedgerEntryType").Uint())
-				le := LedgerEntryFactory[leType]()
-				fields := reflect.ValueOf(le)
-				v.Elem().FieldByName(name).Set(fields)
-				if err := readObject(r, &fields); err != nil && err != errorEndOfObject {
-					return err
-				}
-				// var fields Fields
-				// f := reflect.ValueOf(&fields)
-				// v.Elem().FieldByName(name).Set(f)
-				// if readObject(r, &f); err != nil && err != errorEndOfObject {
-				// 	return err
-				// }
-			case "ModifiedNode", "DeletedNode", "CreatedNode":
-				var node AffectedNode
-				n := reflect.ValueOf(&node)
-				var effect NodeEffect
-				e := reflect.ValueOf(&effect)
-				e.Elem().FieldByName(name).Set(n)
-				v.Set(e.Elem())
-				return readObject(r, &n)
-			case "SignerEntry":
-				var signerEntry SignerEntryItem
-				s := reflect.ValueOf(&signerEntry)
-				err := readObject(r, &s)
-				v.FieldByName("SignerEntry").Set(s.Elem())
-				return err
-			case "NFToken":
-				var nft NFToken
-				s := reflect.ValueOf(&nft)
-				err := readObject(r, &s)
-				v.Set(s.Elem())
-				return err
-			case "Signer":
-				var signer Signer
-				s := reflect.ValueOf(&signer)
-				err := readObject(r, &s)
-				v.Set(s.Elem())
-				return err
-			case "Majority":
-				var majority Majority
-				m := reflect.ValueOf(&majority)
-				err := readObject(r, &m)
-				v.Set(m.Elem())
-				return err
-			case "AuctionSlot":
-				var slot AuctionSlot
-				s := reflect.ValueOf(&slot)
-				err := readObject(r, &s)
-				v.Elem().FieldByName("AuctionSlot").Set(s)
-				if err != errorEndOfObject {
-					return err
-				}
-			case "AuthAccount":
-				var authAccount AuthAccountItem
-				aa := reflect.ValueOf(&authAccount)
-				err := readObject(r, &aa)
-				v.FieldByName("AuthAccount").Set(aa.Elem())
-				return err
-			case "VoteEntry":
-				var VoteEntry VoteEntryItem
-				ve := reflect.ValueOf(&VoteEntry)
-				err := readObject(r, &ve)
-				v.FieldByName("VoteEntry").Set(ve.Elem())
-				return err
-			case "Memo":
-				var memo Memo
-				m := reflect.ValueOf(&memo)
-				inner := reflect.ValueOf(&memo.Memo)
-				err := readObject(r, &inner)
-				v.Set(m.Elem())
-				return err
-			case "DisabledValidator":
-				var disabledValidator DisabledValidator
-				dv := reflect.ValueOf(&disabledValidator)
-				err := readObject(r, &dv)
-				v.Set(dv.Elem())
-				return err
+			return false, fmt.Errorf("Unexpected array: %s for field: %s", v.Type(), name)
+		}
+		for i := 0; ; i++ {
+			child := reflect.New(array.Type().Elem()).Elem()
+			s.push(fmt.Sprintf("[%d]", i))
+			err := readObject(r, &child, s)
+			s.pop()
+			switch err {
+			case errorEndOfArray:
+				return true, nil
+			case errorEndOfObject:
+				array.Set(reflect.Append(*array, child))
 			default:
-				return fmt.Errorf("Unexpected object: %s for field: %s", v.Type(), name)
+				return false, err
+			}
+		}
+	case ST_OBJECT:
+		switch name {
+		case "EndOfObject":
+			return false, errorEndOfObject
+		case "PreviousFields", "NewFields", "FinalFields":
+			leType := LedgerEntryType(v.Elem().FieldByName("LedgerEntryType").Uint())
+			le := LedgerEntryFactory[leType]()
+			fields := reflect.ValueOf(le)
+			v.Elem().FieldByName(name).Set(fields)
+			if err := readObject(r, &fields, s); err != nil && err != errorEndOfObject {
+				return false, err
+			}
+			// var fields Fields
+			// f := reflect.ValueOf(&fields)
+			// v.Elem().FieldByName(name).Set(f)
+			// if readObject(r, &f); err != nil && err != errorEndOfObject {
+			// 	return err
+			// }
+			return true, nil
+		case "ModifiedNode", "DeletedNode", "CreatedNode":
+			var node AffectedNode
+			n := reflect.ValueOf(&node)
+			var effect NodeEffect
+			e := reflect.ValueOf(&effect)
+			e.Elem().FieldByName(name).Set(n)
+			v.Set(e.Elem())
+			return false, readObject(r, &n, s)
+		case "SignerEntry":
+			var signerEntry SignerEntryItem
+			se := reflect.ValueOf(&signerEntry)
+			err := readObject(r, &se, s)
+			v.FieldByName("SignerEntry").Set(se.Elem())
+			return false, err
+		case "NFToken":
+			var nft NFToken
+			n := reflect.ValueOf(&nft)
+			err := readObject(r, &n, s)
+			v.Set(n.Elem())
+			return false, err
+		case "Signer":
+			var signer Signer
+			si := reflect.ValueOf(&signer)
+			err := readObject(r, &si, s)
+			v.Set(si.Elem())
+			return false, err
+		case "Majority":
+			var majority Majority
+			m := reflect.ValueOf(&majority)
+			err := readObject(r, &m, s)
+			v.Set(m.Elem())
+			return false, err
+		case "AuctionSlot":
+			var slot AuctionSlot
+			as := reflect.ValueOf(&slot)
+			err := readObject(r, &as, s)
+			v.Elem().FieldByName("AuctionSlot").Set(as)
+			if err != errorEndOfObject {
+				return false, err
 			}
+			return true, nil
+		case "AuthAccount":
+			var authAccount AuthAccountItem
+			aa := reflect.ValueOf(&authAccount)
+			err := readObject(r, &aa, s)
+			v.FieldByName("AuthAccount").Set(aa.Elem())
+			return false, err
+		case "VoteEntry":
+			var VoteEntry VoteEntryItem
+			ve := reflect.ValueOf(&VoteEntry)
+			err := readObject(r, &ve, s)
+			v.FieldByName("VoteEntry").Set(ve.Elem())
+			return false, err
+		case "Memo":
+			var memo Memo
+			m := reflect.ValueOf(&memo)
+			inner := reflect.ValueOf(&memo.Memo)
+			err := readObject(r, &inner, s)
+			v.Set(m.Elem())
+			return false, err
+		case "DisabledValidator":
+			var disabledValidator DisabledValidator
+			dv := reflect.ValueOf(&disabledValidator)
+			err := readObject(r, &dv, s)
+			v.Set(dv.Elem())
+			return false, err
 		default:
-			if v.Kind() == reflect.Struct {
-				return fmt.Errorf("Unexpected struct: %s for field: %s", v.Type(), name)
+			if ok, err := readUnknown(r, v, enc); ok {
+				return err == nil, err
 			}
-			field := getField(v, enc)
-			if !field.CanAddr() {
-				return fmt.Errorf("Missing field: %s %+v", name, enc)
+			return false, fmt.Errorf("Unexpected object: %s for field: %s", v.Type(), name)
+		}
+	default:
+		if v.Kind() == reflect.Struct {
+			return false, fmt.Errorf("Unexpected struct: %s for field: %s", v.Type(), name)
+		}
+		field := getField(v, enc)
+		if !field.IsValid() {
+			if ok, err := readUnknown(r, v, enc); ok {
+				return err == nil, err
 			}
-			switch v := field.Addr().Interface().(type) {
-			case Wire:
-				if err := v.Unmarshal(r); err != nil {
-					return err
-				}
-			default:
-				if err := read(r, v); err != nil {
-					return err
-				}
+		}
+		if !field.CanAddr() {
+			return false, fmt.Errorf("Missing field: %s %+v", name, enc)
+		}
+		switch v := field.Addr().Interface().(type) {
+		case Wire:
+			if err := v.Unmarshal(r); err != nil {
+				return false, err
+			}
+		default:
+			if err := read(r, v); err != nil {
+				return false, err
 			}
 		}
+		return true, nil
 	}
-	return err
 }
 
 func getField(v *reflect.Value, e *enc) *reflect.Value {
@@ -0,0 +1,82 @@
+package data
+
+import (
+	"fmt"
+)
+
+// Delivery is the amount which a Payment or CheckCash delivered to its
+// destination, which for a partial payment may be far less than the amount
+// requested
+type Delivery struct {
+	Destination Account
+	Requested   Amount
+	Delivered   Amount
+	// Partial is set for a Payment with tfPartialPayment or a CheckCash
+	// with DeliverMin, either of which may deliver less than Requested
+	Partial bool
+	// Derived is set when Delivered was worked out from balance changes,
+	// as metadata before 2014 has no DeliveredAmount
+	Derived bool
+}
+
+func (d Delivery) String() string {
+	return fmt.Sprintf("Delivered: %-34s  %s of %s Partial: %t Derived: %t", d.Destination, d.Delivered, d.Requested, d.Partial, d.Derived)
+}
+
+// Delivered returns what a Payment or CheckCash delivered, which is nothing
+// when the transaction failed, or nil for other transaction types
+func (txm *TransactionWithMetaData) Delivered() (*Delivery, error) {
+	var d Delivery
+	switch tx := txm.Transaction.(type) {
+	case *Payment:
+		d.Destination, d.Requested = tx.Destination, tx.Amount
+		d.Partial = tx.Flags != nil && *tx.Flags&TxPartialPayment != 0
+	case *CheckCash:
+		d.Destination = tx.Account
+		switch {
+		case tx.Amount != nil:
+			d.Requested = *tx.Amount
+		case tx.DeliverMin != nil:
+			d.Requested, d.Partial = *tx.DeliverMin, true
+		default:
+			return nil, fmt.Errorf("CheckCash has neither Amount nor DeliverMin")
+		}
+	default:
+		return nil, nil
+	}
+	switch {
+	case !txm.MetaData.TransactionResult.Success():
+		d.Delivered = *d.Requested.ZeroClone()
+	case txm.MetaData.DeliveredAmount != nil:
+		d.Delivered = *txm.MetaData.DeliveredAmount
+	case !d.Partial:
+		d.Delivered = d.Requested
+	default:
+		delivered, err := txm.received(d.Destination, &d.Requested)
+		if err != nil {
+			return nil, err
+		}
+		d.Delivered, d.Derived = *delivered, true
+	}
+	return &d, nil
+}
+
+// received returns the total change in the balances of account in the
+// currency of like, whichever the issuer. The fee is excluded from a change
+// in the XRP balance of the sender.
+func (txm *TransactionWithMetaData) received(account Account, like *Amount) (*Amount, error) {
+	changes, err := txm.BalanceChanges()
+	if err != nil {
+		return nil, err
+	}
+	total := like.ZeroClone()
+	for _, c := range changes {
+		if c.Account != account || c.NFTokenID != nil || c.Reason == ReasonFee || !c.Currency.Equals(like.Currency) {
+			continue
+		}
+		if total.Value, err = total.Value.Add(c.Change); err != nil {
+			return nil, err
+		}
+	}
+	return total, nil
+}
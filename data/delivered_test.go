@@ -0,0 +1,94 @@
+package data
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func readPayment(t *testing.T, path string) *TransactionWithMetaData {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var txm TransactionWithMetaData
+	if err := json.Unmarshal(b, &txm); err != nil {
+		t.Fatal(err)
+	}
+	var meta struct {
+		Meta MetaData
+	}
+	if err := json.Unmarshal(b, &meta); err != nil {
+		t.Fatal(err)
+	}
+	txm.MetaData = meta.Meta
+	return &txm
+}
+
+func checkDelivery(t *testing.T, txm *TransactionWithMetaData, delivered string, partial, derived bool) {
+	t.Helper()
+	d, err := txm.Delivered()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := NewAmount(delivered)
+	if err != nil {
+		t.Fatal(err)
+	}
+	switch {
+	case !d.Delivered.Equals(*want):
+		t.Errorf("Expected delivered %s got %s", want, d.Delivered)
+	case d.Partial != partial:
+		t.Errorf("Expected partial %t got %t", partial, d.Partial)
+	case d.Derived != derived:
+		t.Errorf("Expected derived %t got %t", derived, d.Derived)
+	}
+}
+
+func TestDelivered(t *testing.T) {
+	partial := TxPartialPayment
+
+	ledger := readLedger(t, "testdata/ledger_6000000.json")
+	txm := ledger.Transactions[0]
+	checkDelivery(t, txm, "10000000000", false, false)
+	txm.GetBase().Flags = &partial
+	checkDelivery(t, txm, "10000000000", true, true)
+	txm.MetaData.DeliveredAmount, _ = NewAmount("1")
+	checkDelivery(t, txm, "1", true, false)
+	txm.MetaData.TransactionResult = tecPATH_PARTIAL
+	checkDelivery(t, txm, "0", true, false)
+
+	txm = readPayment(t, "testdata/transaction_payment_with_rippling.json")
+	checkDelivery(t, txm, "20/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", false, false)
+	txm.GetBase().Flags = &partial
+	checkDelivery(t, txm, "20/USD/rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B", true, true)
+
+	var meta MetaData
+	if err := json.Unmarshal([]byte(`{"TransactionResult":"tesSUCCESS","delivered_amount":"unavailable"}`), &meta); err != nil {
+		t.Fatal(err)
+	}
+	if meta.DeliveredAmount != nil {
+		t.Errorf("Expected no delivered amount got %s", meta.DeliveredAmount)
+	}
+}
+
+// A partial CheckCash of an XRP Check, with metadata lacking DeliveredAmount
+const checkCash = `{
+	"TransactionType": "CheckCash", "Account": "DESTINATION", "DeliverMin": "500000",
+	"CheckID": "0000000000000000000000000000000000000000000000000000000000000003",
+	"Fee": "12", "Sequence": 1, "hash": "4444444444444444444444444444444444444444444444444444444444444444",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000001",
+			"FinalFields": {"Account": "OWNER", "Balance": "9200000", "Flags": 0, "OwnerCount": 0, "Sequence": 2},
+			"PreviousFields": {"Balance": "10000000", "OwnerCount": 1}}},
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000004",
+			"FinalFields": {"Account": "DESTINATION", "Balance": "1799988", "Flags": 0, "OwnerCount": 0, "Sequence": 2},
+			"PreviousFields": {"Balance": "1000000", "Sequence": 1}}},
+		{"DeletedNode": {"LedgerEntryType": "Check", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000003",
+			"FinalFields": {"Account": "OWNER", "Destination": "DESTINATION", "SendMax": "1000000", "Sequence": 1}}}
+	]}
+}`
+
+func TestDeliveredCheckCash(t *testing.T) {
+	checkDelivery(t, parseTransaction(t, checkCash), "800000", true, true)
+}
@@ -123,8 +123,15 @@ func writeRaw(w io.Writer, value interface{}, ignoreSigningFields bool) error {
 }
 
 func encode(w io.Writer, value interface{}, ignoreSigningFields bool) error {
+	if codec, ok := generated(value); ok {
+		return codec.marshal(w, ignoreSigningFields, false)
+	}
+	return encodeFields(w, value, 0, ignoreSigningFields)
+}
+
+func encodeFields(w io.Writer, value interface{}, depth int, ignoreSigningFields bool) error {
 	v := reflect.Indirect(reflect.ValueOf(value))
-	fields := getFields(&v, 0)
+	fields := getFields(&v, depth)
 	// fmt.Println(fields.String())
 	return fields.Each(func(e enc, v interface{}) error {
 		if ignoreSigningFields && e.SigningField() {
@@ -135,6 +142,8 @@ func encode(w io.Writer, value interface{}, ignoreSigningFields bool) error {
 		}
 		var err error
 		switch v2 := v.(type) {
+		case rawValue:
+			_, err = w.Write(v2)
 		case Wire:
 			err = v2.Marshal(w)
 		case nil:
@@ -178,8 +187,14 @@ func getFields(v *reflect.Value, depth int) fieldSlice {
 		if fieldName == "LedgerEntryType" && depth > 1 && typ.Name() == "leBase" {
 			continue
 		}
-		encoding := reverseEncodings[fieldName]
 		f := v.Field(i)
+		if f.Type() == unknownFieldsType {
+			for e, b := range f.Interface().(UnknownFields) {
+				fields.Append(e, rawValue(b), nil)
+			}
+			continue
+		}
+		encoding := reverseEncodings[fieldName]
 		// fmt.Println(fieldName, encoding, f, f.Kind())
 		if f.Kind() == reflect.Interface {
 			f = f.Elem()
@@ -201,7 +216,7 @@ func getFields(v *reflect.Value, depth int) fieldSlice {
 			var children fieldSlice
 			for i := 0; i < f.Len(); i++ {
 				f2 := f.Index(i)
-				children = append(children, getFields(&f2, depth+1)...)
+				children = append(children, getElementFields(&f2, depth+1)...)
 			}
 			children.Append(reverseEncodings["EndOfArray"], nil, nil)
 			fields.Append(encoding, nil, children)
@@ -217,6 +232,20 @@ func getFields(v *reflect.Value, depth int) fieldSlice {
 	return fields
 }
 
+// getElementFields returns the fields of an array element. Most elements
+// wrap their object in a field of the same name, as Memo does, but some
+// such as Majority are the object itself and need its header adding.
+func getElementFields(v *reflect.Value, depth int) fieldSlice {
+	name := v.Type().Name()
+	encoding, ok := reverseEncodings[name]
+	if _, wrapper := v.Type().FieldByName(name); !ok || encoding.typ != ST_OBJECT || wrapper {
+		return getFields(v, depth)
+	}
+	children := getFields(v, depth+1)
+	children.Append(reverseEncodings["EndOfObject"], nil, nil)
+	return fieldSlice{{encoding, nil, children}}
+}
+
 func (s fieldSlice) Each(f func(e enc, v interface{}) error) error {
 	for _, field := range s {
 		if err := f(field.encoding, field.value); err != nil {
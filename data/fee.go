@@ -0,0 +1,54 @@
+package data
+
+import (
+	"fmt"
+)
+
+// Drops returns the fee of a reference transaction, the account reserve and
+// the owner reserve in drops. Ledgers since the XRPFees amendment give them
+// as amounts rather than integers.
+func (f *FeeSettings) Drops() (base, reserve, increment uint64, err error) {
+	switch {
+	case f.BaseFeeDrops != nil && f.ReserveBaseDrops != nil && f.ReserveIncrementDrops != nil:
+		for _, v := range []struct {
+			amount *Amount
+			drops  *uint64
+		}{
+			{f.BaseFeeDrops, &base},
+			{f.ReserveBaseDrops, &reserve},
+			{f.ReserveIncrementDrops, &increment},
+		} {
+			if v.amount.Value == nil || !v.amount.IsNative() || v.amount.IsNegative() {
+				return 0, 0, 0, fmt.Errorf("Bad FeeSettings amount: %s", v.amount)
+			}
+			*v.drops = v.amount.num
+		}
+		return base, reserve, increment, nil
+	case f.BaseFee != nil && f.ReserveBase != nil && f.ReserveIncrement != nil:
+		return uint64(*f.BaseFee), uint64(*f.ReserveBase), uint64(*f.ReserveIncrement), nil
+	default:
+		return 0, 0, 0, fmt.Errorf("Incomplete FeeSettings")
+	}
+}
+
+// TransactionFee returns the fee in drops of tx before any load scaling.
+// baseFee is the fee of a reference transaction and increment is the owner
+// reserve, which is the cost of AccountDelete and AMMCreate. signers is the
+// number of signatures a multisigned transaction will carry, each of which
+// costs a further base fee, or zero if tx is singly signed.
+func TransactionFee(tx Transaction, baseFee, increment uint64, signers int) uint64 {
+	switch v := tx.(type) {
+	case *Amendment, *SetFee, *UNLModify:
+		return 0
+	case *AccountDelete, *AMMCreate:
+		return increment
+	case *EscrowFinish:
+		fee := baseFee * uint64(1+signers)
+		if v.Fulfillment != nil {
+			fee += EscrowFinishFee(baseFee, *v.Fulfillment) - baseFee
+		}
+		return fee
+	default:
+		return baseFee * uint64(1+signers)
+	}
+}
@@ -0,0 +1,27 @@
+package data
+
+import "testing"
+
+func TestFeeSettingsDrops(t *testing.T) {
+	settings := &FeeSettings{
+		BaseFeeDrops:          drops(t, 10),
+		ReserveBaseDrops:      drops(t, 1000000),
+		ReserveIncrementDrops: drops(t, 200000),
+	}
+	base, reserve, increment, err := settings.Drops()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base != 10 || reserve != 1000000 || increment != 200000 {
+		t.Fatalf("Bad drops: %d %d %d", base, reserve, increment)
+	}
+	if _, _, _, err := (&FeeSettings{}).Drops(); err == nil {
+		t.Fatal("Empty FeeSettings was accepted")
+	}
+	if fee := TransactionFee(&AccountDelete{}, base, increment, 2); fee != increment {
+		t.Fatalf("Expected AccountDelete fee of %d got: %d", increment, fee)
+	}
+	if fee := TransactionFee(&SetFee{}, base, increment, 0); fee != 0 {
+		t.Fatalf("Expected no fee for pseudo-transaction got: %d", fee)
+	}
+}
@@ -54,6 +54,25 @@ const (
 	// PaymentChannelClaim flags
 	TxRenew TransactionFlag = 0x00010000
 	TxClose TransactionFlag = 0x00020000
+
+	// NFTokenMint flags
+	TxBurnable     TransactionFlag = 0x00000001
+	TxOnlyXRP      TransactionFlag = 0x00000002
+	TxTrustLine    TransactionFlag = 0x00000004
+	TxTransferable TransactionFlag = 0x00000008
+
+	// NFTokenCreateOffer flags
+	TxSellNFToken TransactionFlag = 0x00000001
+
+	// AMMDeposit and AMMWithdraw flags
+	TxLPToken             TransactionFlag = 0x00010000
+	TxWithdrawAll         TransactionFlag = 0x00020000
+	TxOneAssetWithdrawAll TransactionFlag = 0x00040000
+	TxSingleAsset         TransactionFlag = 0x00080000
+	TxTwoAsset            TransactionFlag = 0x00100000
+	TxOneAssetLPToken     TransactionFlag = 0x00200000
+	TxLimitLPToken        TransactionFlag = 0x00400000
+	TxTwoAssetIfEmpty     TransactionFlag = 0x00800000
 )
 
 var txFlagNames = map[TransactionType][]struct {
@@ -119,6 +138,7 @@ const (
 	LsHighNoRipple LedgerEntryFlag = 0x00200000
 	LsLowFreeze    LedgerEntryFlag = 0x00400000
 	LsHighFreeze   LedgerEntryFlag = 0x00800000
+	LsAMMNode      LedgerEntryFlag = 0x01000000
 
 	// SignerList flags
 	LsOneOwnerCount LedgerEntryFlag = 0x00010000
@@ -156,6 +176,7 @@ var leFlagNames = map[LedgerEntryType][]struct {
 		{LsHighNoRipple, "HighNoRipple"},
 		{LsLowFreeze, "LowFreeze"},
 		{LsHighFreeze, "HighFreeze"},
+		{LsAMMNode, "AMMNode"},
 	},
 	SIGNER_LIST: {
 		{LsOneOwnerCount, "OneOwnerCount"},
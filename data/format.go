@@ -23,6 +23,7 @@ const (
 	HP_TRANSACTION_MULTISIGN HashPrefix = 0x534D5400 // 'SMT' inner transaction to multi-sign
 	HP_VALIDATION            HashPrefix = 0x56414C00 // 'VAL' validation for signing
 	HP_PROPOSAL              HashPrefix = 0x50525000 // 'PRP' proposal for signing
+	HP_PAYMENT_CHANNEL_CLAIM HashPrefix = 0x434C4D00 // 'CLM' payment channel claim
 
 	// Node Types
 	NT_UNKNOWN          NodeType = 0
@@ -371,7 +372,8 @@ func init() {
 	signingFields = make(map[enc]struct{})
 	for e, name := range encodings {
 		reverseEncodings[name] = e
-		if strings.Contains(name, "Signature") {
+		// The Signers of a multisigned transaction are not signed either
+		if strings.Contains(name, "Signature") || name == "Signers" {
 			signingFields[e] = struct{}{}
 		}
 	}
@@ -400,10 +402,24 @@ func (e enc) SigningField() bool {
 	return ok
 }
 
+// FieldCode returns the serialized type and field codes of the named field
+func FieldCode(name string) (typ, field uint8, ok bool) {
+	e, ok := reverseEncodings[name]
+	return e.typ, e.field, ok
+}
+
 func readEncoding(r Reader) (*enc, error) {
+	e, err := readFieldHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+func readFieldHeader(r Reader) (enc, error) {
 	var e enc
 	if b, err := r.ReadByte(); err != nil {
-		return nil, err
+		return e, err
 	} else {
 		e.typ = b >> 4
 		e.field = b & 0xF
@@ -411,15 +427,15 @@ func readEncoding(r Reader) (*enc, error) {
 	var err error
 	if e.typ == 0 {
 		if e.typ, err = r.ReadByte(); err != nil {
-			return nil, err
+			return e, err
 		}
 	}
 	if e.field == 0 {
 		if e.field, err = r.ReadByte(); err != nil {
-			return nil, err
+			return e, err
 		}
 	}
-	return &e, nil
+	return e, nil
 }
 
 func writeEncoding(w io.Writer, e enc) error {
@@ -183,15 +183,47 @@ func (p *PublicKey) Bytes() []byte {
 	return []byte(nil)
 }
 
-// Expects address in base58 form
+// Expects address in base58 form, either a classic address or an X-address
+// without a destination tag. Use ParseAddress to accept tagged X-addresses.
 func NewAccountFromAddress(s string) (*Account, error) {
-	hash, err := crypto.NewRippleHashCheck(s, crypto.RIPPLE_ACCOUNT_ID)
+	account, tag, err := ParseAddress(s)
 	if err != nil {
 		return nil, err
 	}
+	if tag != nil {
+		return nil, fmt.Errorf("X-address %s has destination tag %d which cannot be used here", s, *tag)
+	}
+	return account, nil
+}
+
+// ParseAddress parses a classic address or an X-address, returning the
+// destination tag of an X-address if it has one
+func ParseAddress(s string) (*Account, *uint32, error) {
 	var account Account
+	if crypto.IsXAddress(s) {
+		id, tag, _, err := crypto.DecodeXAddress(s)
+		if err != nil {
+			return nil, nil, err
+		}
+		copy(account[:], id)
+		return &account, tag, nil
+	}
+	hash, err := crypto.NewRippleHashCheck(s, crypto.RIPPLE_ACCOUNT_ID)
+	if err != nil {
+		return nil, nil, err
+	}
 	copy(account[:], hash.Payload())
-	return &account, nil
+	return &account, nil, nil
+}
+
+// XAddress returns the X-address of the account with an optional
+// destination tag for mainnet or testnet
+func (a Account) XAddress(tag *uint32, testnet bool) string {
+	address, err := crypto.EncodeXAddress(a[:], tag, testnet)
+	if err != nil {
+		return fmt.Sprintf("Bad Address: %s", b2h(a[:]))
+	}
+	return address
 }
 
 func (a Account) Hash() (crypto.Hash, error) {
@@ -276,6 +308,34 @@ func NewSeedFromAddress(s string) (*Seed, error) {
 	return &seed, nil
 }
 
+// NewSeedFromRFC1751 decodes a seed from the 12 RFC 1751 words returned as
+// key by rippled's wallet_propose
+func NewSeedFromRFC1751(words string) (*Seed, error) {
+	b, err := crypto.RFC1751Decode(words)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != len(zeroSeed) {
+		return nil, fmt.Errorf("Seed is wrong size, expected: 12 words got: %d", len(b)/8*6)
+	}
+	var seed Seed
+	for i := range seed {
+		seed[i] = b[len(b)-1-i]
+	}
+	return &seed, nil
+}
+
+// RFC1751 returns the seed as 12 RFC 1751 words. Like rippled, the bytes of
+// the seed are reversed before encoding.
+func (s Seed) RFC1751() string {
+	var reversed Seed
+	for i := range s {
+		reversed[i] = s[len(s)-1-i]
+	}
+	words, _ := crypto.RFC1751Encode(reversed[:])
+	return words
+}
+
 func (s Seed) Hash() (crypto.Hash, error) {
 	return crypto.NewFamilySeed(s[:])
 }
@@ -317,3 +377,23 @@ func (s *Seed) AccountId(keyType KeyType, sequence *uint32) Account {
 	copy(account[:], s.Key(keyType).Id(sequence))
 	return account
 }
+
+// NewMnemonicKey derives the key of a hardware wallet style account from a
+// BIP39 mnemonic and optional passphrase. ECDSA keys use the BIP32 path
+// m/44'/144'/account'/0/index and Ed25519 keys the SLIP-10 path
+// m/44'/144'/account'/0'/index'. The key has no account family, so it is
+// used with a nil sequence.
+func NewMnemonicKey(mnemonic, passphrase string, keyType KeyType, account, index uint32) (crypto.Key, error) {
+	seed, err := crypto.MnemonicSeed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	switch keyType {
+	case ECDSA:
+		return crypto.NewBIP32Key(seed, crypto.BIP44Path(account, index))
+	case Ed25519:
+		return crypto.NewSLIP10Key(seed, crypto.SLIP10Path(account, index))
+	default:
+		return nil, fmt.Errorf("Unknown key type: %d", keyType)
+	}
+}
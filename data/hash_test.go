@@ -1,6 +1,7 @@
 package data
 
 import (
+	"bytes"
 	"encoding/hex"
 	"testing"
 )
@@ -39,3 +40,71 @@ func decodeHex(s string, t *testing.T) []byte {
 // 		t.Log(tx)
 // 	}
 // }
+
+func TestMnemonicKey(t *testing.T) {
+	const mnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	// The accounts of a hardware wallet at m/44'/144'/0'/0/0, and at
+	// m/44'/144'/0'/0'/0' by SLIP-10
+	for keyType, address := range map[KeyType]string{
+		ECDSA:   "rHsMGQEkVNJmpGWs8XUBoTBiAAbwxZN5v3",
+		Ed25519: "rP8Cn7F5SJP5SVzwwbriX4YGuzYSmms6Cg",
+	} {
+		key, err := NewMnemonicKey(mnemonic, "", keyType, 0, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var account Account
+		copy(account[:], key.Id(nil))
+		if account.String() != address {
+			t.Fatalf("%s: Expected %s got %s", keyType, address, account)
+		}
+		other, err := NewMnemonicKey(mnemonic, "", keyType, 0, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if bytes.Equal(key.Id(nil), other.Id(nil)) {
+			t.Fatalf("%s: indexes 0 and 1 give the same account", keyType)
+		}
+		amount, err := NewAmount("1000000")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fee, err := NewValue("10", true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payment := &Payment{TxBase: TxBase{TransactionType: PAYMENT, Fee: *fee}, Amount: *amount}
+		copy(payment.Account[:], key.Id(nil))
+		if err := Sign(payment, key, nil); err != nil {
+			t.Fatal(err)
+		}
+		if ok, err := CheckSignature(payment); !ok || err != nil {
+			t.Fatalf("%s: bad signature: %v", keyType, err)
+		}
+	}
+	if _, err := NewMnemonicKey(mnemonic+" abandon", "", ECDSA, 0, 0); err == nil {
+		t.Fatal("expected bad mnemonic length")
+	}
+}
+
+func TestSeedRFC1751(t *testing.T) {
+	// From rippled's wallet_propose for "masterpassphrase"
+	const words = "I IRE BOND BOW TRIO LAID SEAT GOAL HEN IBIS IBIS DARE"
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := seed.RFC1751(); got != words {
+		t.Fatalf("Expected: %s got: %s", words, got)
+	}
+	decoded, err := NewSeedFromRFC1751(words)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *decoded != *seed {
+		t.Fatalf("Expected: %s got: %s", seed, decoded)
+	}
+	if _, err := NewSeedFromRFC1751("TIDE ITCH SLOW REIN RULE MOT"); err == nil {
+		t.Fatal("Expected error for 6 word key")
+	}
+}
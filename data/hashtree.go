@@ -0,0 +1,182 @@
+package data
+
+import (
+	"crypto/sha512"
+	"fmt"
+)
+
+// HashTree is the tree of leaf hashes from which the hash of a ledger's
+// state or transactions is calculated. Each inner node has 16 branches
+// chosen by the next nibble of the index, and a leaf is held at the
+// shallowest depth at which no other index shares its branch. The hashes
+// of inner nodes are kept until a change beneath them.
+type HashTree struct {
+	root hashNode
+}
+
+type hashNode struct {
+	children [16]*hashNode
+	inner    bool
+	dirty    bool
+	index    Hash256 // leaves only
+	hash     Hash256
+}
+
+func NewHashTree() *HashTree {
+	return &HashTree{root: hashNode{inner: true}}
+}
+
+func nibble(index Hash256, depth int) int {
+	if depth%2 == 0 {
+		return int(index[depth/2] >> 4)
+	}
+	return int(index[depth/2] & 0x0F)
+}
+
+// Set adds or replaces the leaf for index
+func (t *HashTree) Set(index, hash Hash256) {
+	node := &t.root
+	for depth := 0; ; depth++ {
+		node.dirty = true
+		i := nibble(index, depth)
+		child := node.children[i]
+		switch {
+		case child == nil:
+			node.children[i] = &hashNode{index: index, hash: hash}
+			return
+		case child.inner:
+			node = child
+		case child.index == index:
+			child.hash = hash
+			return
+		default:
+			split := &hashNode{inner: true}
+			split.children[nibble(child.index, depth+1)] = child
+			node.children[i] = split
+			node = split
+		}
+	}
+}
+
+// Delete removes the leaf for index and returns whether it was present
+func (t *HashTree) Delete(index Hash256) bool {
+	return t.root.delete(index, 0)
+}
+
+func (n *hashNode) delete(index Hash256, depth int) bool {
+	i := nibble(index, depth)
+	child := n.children[i]
+	switch {
+	case child == nil:
+		return false
+	case !child.inner:
+		if child.index != index {
+			return false
+		}
+		n.children[i] = nil
+	default:
+		if !child.delete(index, depth+1) {
+			return false
+		}
+		// An inner node left with a single leaf is replaced by the leaf
+		if leaf := child.onlyLeaf(); leaf != nil {
+			n.children[i] = leaf
+		}
+	}
+	n.dirty = true
+	return true
+}
+
+func (n *hashNode) onlyLeaf() *hashNode {
+	var only *hashNode
+	for _, child := range n.children {
+		if child != nil {
+			if only != nil {
+				return nil
+			}
+			only = child
+		}
+	}
+	if only == nil || only.inner {
+		return nil
+	}
+	return only
+}
+
+// Hash returns the hash of the root of the tree, which is zero when the
+// tree is empty
+func (t *HashTree) Hash() (Hash256, error) {
+	if t.root.empty() {
+		return zero256, nil
+	}
+	return t.root.nodeHash()
+}
+
+func (n *hashNode) empty() bool {
+	for _, child := range n.children {
+		if child != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *hashNode) nodeHash() (Hash256, error) {
+	if !n.inner || !n.dirty {
+		return n.hash, nil
+	}
+	var inner InnerNode
+	for i, child := range n.children {
+		if child == nil {
+			continue
+		}
+		hash, err := child.nodeHash()
+		if err != nil {
+			return zero256, err
+		}
+		inner.Children[i] = hash
+	}
+	hash, err := NodeId(&inner)
+	if err != nil {
+		return zero256, err
+	}
+	n.hash, n.dirty = hash, false
+	return hash, nil
+}
+
+// LeafHash returns the hash of le as a leaf of the state tree
+func LeafHash(le LedgerEntry) (Hash256, error) {
+	index := entryIndex(le)
+	if index == nil {
+		return zero256, fmt.Errorf("%s has no LedgerIndex", le.GetType())
+	}
+	// The LedgerIndex follows the fields rather than being one of them
+	le = cloneEntry(le)
+	entryBase(le).LedgerIndex = nil
+	hasher := sha512.New()
+	if err := write(hasher, HP_LEAF_NODE); err != nil {
+		return zero256, err
+	}
+	if err := encode(hasher, le, false); err != nil {
+		return zero256, err
+	}
+	if err := write(hasher, *index); err != nil {
+		return zero256, err
+	}
+	var hash Hash256
+	copy(hash[:], hasher.Sum(nil))
+	return hash, nil
+}
+
+// Tree returns the HashTree of the entries of the State
+func (s State) Tree() (*HashTree, error) {
+	t := NewHashTree()
+	for index, le := range s {
+		hash, err := LeafHash(le)
+		if err != nil {
+			return nil, err
+		}
+		t.Set(index, hash)
+	}
+	return t, nil
+}
@@ -0,0 +1,45 @@
+package data
+
+import "testing"
+
+func TestHashTree(t *testing.T) {
+	ledger := readLedger(t, "testdata/ledger_6000000.json")
+	state, err := NewState(ledger.AccountState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := state.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := tree.Hash()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != ledger.StateHash {
+		t.Fatalf("Expected state hash %s got %s", ledger.StateHash, hash)
+	}
+
+	// Removing and restoring every entry returns to the same hash
+	for index := range state {
+		if !tree.Delete(index) {
+			t.Fatalf("Missing %s", index)
+		}
+	}
+	if hash, err := tree.Hash(); err != nil || hash != zero256 {
+		t.Fatalf("Expected zero hash for empty tree got %s %v", hash, err)
+	}
+	for index, le := range state {
+		leaf, err := LeafHash(le)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree.Set(index, leaf)
+	}
+	if hash, err := tree.Hash(); err != nil || hash != ledger.StateHash {
+		t.Fatalf("Expected state hash %s got %s %v", ledger.StateHash, hash, err)
+	}
+	if tree.Delete(zero256) {
+		t.Fatal("Deleted missing index")
+	}
+}
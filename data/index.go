@@ -83,6 +83,14 @@ func GetBookIndex(paysCurrency, getsCurrency Hash160, paysIssuer, getsIssuer Has
 	return index, nil
 }
 
+func GetSignerListIndex(account Account) (*Hash256, error) {
+	return buildIndex([]interface{}{NS_SIGNER_LIST, account.Bytes(), uint32(0)})
+}
+
+func GetPayChannelIndex(source, destination Account, sequence uint32) (*Hash256, error) {
+	return buildIndex([]interface{}{NS_XRPU_CHANNEL, source.Bytes(), destination.Bytes(), sequence})
+}
+
 func GetFeeIndex() (*Hash256, error) {
 	return buildIndex([]interface{}{NS_FEE})
 }
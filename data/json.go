@@ -248,6 +248,65 @@ func (a *AffectedNode) MarshalJSON() ([]byte, error) {
 	return []byte(fixed), err
 }
 
+// Accepts an X-address as the Destination, see SetDestination
+func (p *Payment) UnmarshalJSON(b []byte) error {
+	type payment Payment
+	var v struct {
+		*payment
+		Destination string
+	}
+	v.payment = (*payment)(p)
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if len(v.Destination) == 0 {
+		return nil
+	}
+	return p.SetDestination(v.Destination)
+}
+
+// Accepts a delivered_amount of "unavailable", which rippled returns for
+// transactions from before DeliveredAmount was recorded, as no amount
+func (m *MetaData) UnmarshalJSON(b []byte) error {
+	type metaData MetaData
+	var v struct {
+		*metaData
+		DeliveredAmount json.RawMessage `json:"delivered_amount"`
+	}
+	v.metaData = (*metaData)(m)
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if len(v.DeliveredAmount) == 0 || string(v.DeliveredAmount) == `"unavailable"` {
+		return nil
+	}
+	m.DeliveredAmount = new(Amount)
+	return json.Unmarshal(v.DeliveredAmount, m.DeliveredAmount)
+}
+
+// Reads owner_funds in the same units as TakerGets, which are drips when
+// TakerGets is XRP
+func (o *OrderBookOffer) UnmarshalJSON(b []byte) error {
+	type orderBookOffer OrderBookOffer
+	var v struct {
+		*orderBookOffer
+		OwnerFunds *string `json:"owner_funds"`
+	}
+	v.orderBookOffer = (*orderBookOffer)(o)
+	if err := json.Unmarshal(b, &v); err != nil {
+		return err
+	}
+	if v.OwnerFunds == nil {
+		return nil
+	}
+	funds, err := NewValue(*v.OwnerFunds, o.TakerGets != nil && o.TakerGets.IsNative())
+	if err != nil {
+		return err
+	}
+	o.OwnerFunds = *funds
+	return nil
+}
+
 func (i NodeIndex) MarshalText() ([]byte, error) {
 	return []byte(fmt.Sprintf("%016X", i)), nil
 }
@@ -434,7 +493,7 @@ func (a Account) MarshalText() ([]byte, error) {
 	return address.MarshalText()
 }
 
-// Expects base58-encoded account id
+// Expects base58-encoded account id or an X-address without a tag
 func (a *Account) UnmarshalText(b []byte) error {
 	if len(b) == 0 {
 		return nil
@@ -522,3 +581,35 @@ func (h *Uint64Hex) UnmarshalText(b []byte) error {
 func (keyType KeyType) MarshalText() ([]byte, error) {
 	return []byte(keyType.String()), nil
 }
+
+// UnmarshalJSON accepts either a name, as UnmarshalText does, or the number
+// which KeyType was written as before it had names
+func (keyType *KeyType) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err == nil {
+		return keyType.UnmarshalText([]byte(name))
+	}
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("Bad key type: %s", b)
+	}
+	switch KeyType(n) {
+	case ECDSA, Ed25519:
+		*keyType = KeyType(n)
+		return nil
+	}
+	return fmt.Errorf("Unknown key type: %d", n)
+}
+
+// UnmarshalText accepts the names used by MarshalText and rippled's key_type
+func (keyType *KeyType) UnmarshalText(b []byte) error {
+	switch strings.ToLower(string(b)) {
+	case "ecdsa", "secp256k1":
+		*keyType = ECDSA
+	case "ed25519":
+		*keyType = Ed25519
+	default:
+		return fmt.Errorf("Unknown key type: %s", b)
+	}
+	return nil
+}
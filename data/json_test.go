@@ -51,3 +51,36 @@ func (s *JSONSuite) TestLedgersJSON(c *C) {
 		compare(c, f, b, out)
 	}
 }
+
+func (s *JSONSuite) TestPaymentXAddress(c *C) {
+	const classic = "rGWrZyQqhTp9Xu7G5Pkayo7bXjH4k4QYpf"
+	for _, test := range []struct {
+		JSON  string
+		Tag   *uint32
+		Error string
+	}{
+		{`{"Destination":"XVLhHMPHU98es4dbozjVtdWzVrDjtV5fdx1mHp98tDMoQXb"}`, nil, ""},
+		{`{"Destination":"XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk"}`, newUint32(11747), ""},
+		{`{"DestinationTag":11747,"Destination":"XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk"}`, newUint32(11747), ""},
+		{`{"DestinationTag":14,"Destination":"` + classic + `"}`, newUint32(14), ""},
+		{`{"DestinationTag":14,"Destination":"XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk"}`, nil, ".*conflicts with DestinationTag 14"},
+	} {
+		var payment Payment
+		err := json.Unmarshal([]byte(test.JSON), &payment)
+		if test.Error != "" {
+			c.Check(err, ErrorMatches, test.Error)
+			continue
+		}
+		c.Assert(err, IsNil, Commentf(test.JSON))
+		c.Check(payment.Destination.String(), Equals, classic)
+		c.Check(payment.DestinationTag, checkers.DeepEquals, test.Tag)
+	}
+	var account Account
+	c.Check(account.UnmarshalText([]byte("TVE26TYGhfLC7tQDno7G8dGtxSkYQn49b3qD26PK7FcGSKE")), IsNil)
+	c.Check(account.String(), Equals, classic)
+	c.Check(account.XAddress(newUint32(1), true), Equals, "TVE26TYGhfLC7tQDno7G8dGtxSkYQnSz1uDimDdPYXzSpyw")
+	_, err := NewAccountFromAddress("XVLhHMPHU98es4dbozjVtdWzVrDjtV1N75zgFKga4R1B9Mk")
+	c.Check(err, ErrorMatches, ".*has destination tag 11747.*")
+}
+
+func newUint32(v uint32) *uint32 { return &v }
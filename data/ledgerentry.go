@@ -4,11 +4,12 @@ type LedgerEntrySlice []LedgerEntry
 
 type leBase struct {
 	LedgerEntryType   LedgerEntryType
-	LedgerIndex       *Hash256 `json:"index,omitempty"`
-	PreviousTxnID     *Hash256 `json:",omitempty"`
-	PreviousTxnLgrSeq *uint32  `json:",omitempty"`
-	Hash              Hash256  `json:"-"`
-	Id                Hash256  `json:"-"`
+	LedgerIndex       *Hash256      `json:"index,omitempty"`
+	PreviousTxnID     *Hash256      `json:",omitempty"`
+	PreviousTxnLgrSeq *uint32       `json:",omitempty"`
+	Hash              Hash256       `json:"-"`
+	Id                Hash256       `json:"-"`
+	Unknown           UnknownFields `json:"-"`
 }
 
 type AccountRoot struct {
@@ -331,6 +332,7 @@ func (le *leBase) GetHash() *Hash256                   { return &le.Hash }
 func (le *leBase) NodeId() *Hash256                    { return &le.Id }
 func (le *leBase) GetLedgerIndex() *Hash256            { return le.LedgerIndex }
 func (le *leBase) GetPreviousTxnId() *Hash256          { return le.PreviousTxnID }
+func (le *leBase) base() *leBase                       { return le }
 
 func (o *Offer) Ratio() *Value {
 	return o.TakerPays.Ratio(*o.TakerGets)
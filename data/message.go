@@ -0,0 +1,51 @@
+package data
+
+import (
+	"fmt"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+// SignedMessage proves off-ledger that the holder of a key for Account
+// signed Message, such as a challenge from a server
+type SignedMessage struct {
+	Account   Account
+	PublicKey VariableLength
+	Message   string
+	Signature VariableLength
+}
+
+// NewSignedMessage signs message on behalf of account
+func NewSignedMessage(signer crypto.Signer, account Account, message string) (*SignedMessage, error) {
+	public, err := signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	sig, err := crypto.SignMessage(signer, []byte(message))
+	if err != nil {
+		return nil, err
+	}
+	return &SignedMessage{
+		Account:   account,
+		PublicKey: public,
+		Message:   message,
+		Signature: sig,
+	}, nil
+}
+
+// Verify checks the signature of m and that its key may sign for Account.
+// root is the account's AccountRoot, which is needed to accept a regular
+// key. A nil root only accepts the master key.
+func (m *SignedMessage) Verify(root *AccountRoot) (Authority, error) {
+	if root != nil && (root.Account == nil || !root.Account.Equals(m.Account)) {
+		return 0, fmt.Errorf("AccountRoot is not for %s", m.Account)
+	}
+	ok, err := crypto.VerifyMessage(m.PublicKey, []byte(m.Message), m.Signature)
+	switch {
+	case err != nil:
+		return 0, err
+	case !ok:
+		return 0, fmt.Errorf("Bad message signature")
+	}
+	return keyAuthority(crypto.Sha256RipeMD160(m.PublicKey), m.Account, root)
+}
@@ -0,0 +1,51 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+func TestSignedMessage(t *testing.T) {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sequence uint32
+	master := seed.AccountId(ECDSA, &sequence)
+	regular := seed.AccountId(Ed25519, nil)
+	m, err := NewSignedMessage(crypto.NewKeySigner(seed.Key(ECDSA), &sequence), master, "Challenge: 42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded SignedMessage
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if authority, err := decoded.Verify(nil); err != nil || authority != MasterKeyAuthority {
+		t.Fatalf("Expected MasterKey authority got: %s %v", authority, err)
+	}
+	decoded.Message = "Challenge: 43"
+	if _, err := decoded.Verify(nil); err == nil {
+		t.Fatal("Altered message was accepted")
+	}
+
+	// Signed by a regular key
+	m, err = NewSignedMessage(crypto.NewKeySigner(seed.Key(Ed25519), nil), master, "Challenge: 42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Verify(nil); err == nil {
+		t.Fatal("Regular key was accepted without an AccountRoot")
+	}
+	regularKey := RegularKey(regular)
+	root := &AccountRoot{Account: &master, RegularKey: &regularKey}
+	if authority, err := m.Verify(root); err != nil || authority != RegularKeyAuthority {
+		t.Fatalf("Expected RegularKey authority got: %s %v", authority, err)
+	}
+}
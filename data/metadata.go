@@ -35,7 +35,8 @@ type MetaData struct {
 	AffectedNodes     NodeEffects
 	TransactionIndex  uint32
 	TransactionResult TransactionResult
-	DeliveredAmount   *Amount `json:"delivered_amount,omitempty"`
+	DeliveredAmount   *Amount       `json:"delivered_amount,omitempty"`
+	Unknown           UnknownFields `json:"-"`
 }
 
 type TransactionSlice []*TransactionWithMetaData
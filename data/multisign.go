@@ -0,0 +1,176 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+// A multisigned transaction is assembled by giving a copy of the unsigned
+// transaction to each signer, who adds their Signer entry with AddSigner.
+// The copies are merged with CombineSigners, which also checks that every
+// signer signed the same transaction, and the result is checked against the
+// account's SignerList with CheckQuorum before being submitted.
+
+// SignFor returns the Signer entry in which signer signs s on behalf of
+// account. The multisigning data excludes other signatures, so each entry
+// can be made independently and offline.
+func SignFor(s MultiSignable, signer crypto.Signer, account Account) (*Signer, error) {
+	prepareMultiSigning(s)
+	hash, msg, err := MultiSigningHash(s, account)
+	if err != nil {
+		return nil, err
+	}
+	msg = append(s.MultiSigningPrefix().Bytes(), msg...)
+	msg = append(msg, account.Bytes()...)
+	sig, err := signer.Sign(hash.Bytes(), msg)
+	if err != nil {
+		return nil, err
+	}
+	public, err := signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	var pubKey PublicKey
+	if len(public) != len(pubKey) {
+		return nil, fmt.Errorf("Wrong public key length: %d", len(public))
+	}
+	copy(pubKey[:], public)
+	signature := VariableLength(sig)
+	return &Signer{Signer: SignerItem{
+		Account:       account,
+		TxnSignature:  &signature,
+		SigningPubKey: &pubKey,
+	}}, nil
+}
+
+// AddSigner signs s on behalf of account and adds the entry to its Signers,
+// replacing any existing entry for account
+func AddSigner(s MultiSignable, signer crypto.Signer, account Account) error {
+	entry, err := SignFor(s, signer, account)
+	if err != nil {
+		return err
+	}
+	var signers []Signer
+	for _, existing := range s.GetSigners() {
+		if !existing.Signer.Account.Equals(account) {
+			signers = append(signers, existing)
+		}
+	}
+	return SetSigners(s, append(signers, *entry)...)
+}
+
+// prepareMultiSigning sets the empty SigningPubKey that marks a transaction
+// as multisigned and removes any single signature
+func prepareMultiSigning(s MultiSignable) {
+	s.InitialiseForSigning()
+	*s.GetPublicKey() = PublicKey{}
+	if tx, ok := s.(Transaction); ok {
+		tx.GetBase().TxnSignature = nil
+	}
+}
+
+// multiSigningData is the transaction without any signatures
+func multiSigningData(s MultiSignable) ([]byte, error) {
+	_, msg, err := raw(s, s.MultiSigningPrefix(), nil, true)
+	return msg, err
+}
+
+// CombineSigners adds the Signers of partially signed copies of s to s. The
+// copies must have the same multisigning data as s, and any account which
+// appears more than once must have the same signature each time.
+func CombineSigners(s MultiSignable, copies ...MultiSignable) error {
+	prepareMultiSigning(s)
+	expected, err := multiSigningData(s)
+	if err != nil {
+		return err
+	}
+	signers := append([]Signer(nil), s.GetSigners()...)
+	for i, other := range copies {
+		prepareMultiSigning(other)
+		msg, err := multiSigningData(other)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(msg, expected) {
+			return fmt.Errorf("Copy %d is of a different transaction", i)
+		}
+	next:
+		for _, signer := range other.GetSigners() {
+			for _, existing := range signers {
+				if !existing.Signer.Account.Equals(signer.Signer.Account) {
+					continue
+				}
+				if !bytes.Equal(existing.Signer.TxnSignature.Bytes(), signer.Signer.TxnSignature.Bytes()) {
+					return fmt.Errorf("Conflicting signatures for %s", signer.Signer.Account)
+				}
+				continue next
+			}
+			signers = append(signers, signer)
+		}
+	}
+	return SetSigners(s, signers...)
+}
+
+// CheckMultiSignatures verifies the signature of every Signer entry. It does
+// not check that each key is authorised to sign for its account.
+func CheckMultiSignatures(s MultiSignable) error {
+	if pubKey := s.GetPublicKey(); pubKey != nil && !pubKey.IsZero() {
+		return fmt.Errorf("Multisigned transaction has SigningPubKey: %s", pubKey)
+	}
+	signers := s.GetSigners()
+	if len(signers) == 0 {
+		return fmt.Errorf("Transaction has no Signers")
+	}
+	for i, signer := range signers {
+		if i > 0 && !signers[i-1].Signer.Account.Less(signer.Signer.Account) {
+			return fmt.Errorf("Signers are not sorted or have duplicates at: %s", signer.Signer.Account)
+		}
+		account := signer.Signer.Account
+		hash, msg, err := MultiSigningHash(s, account)
+		if err != nil {
+			return err
+		}
+		msg = append(s.MultiSigningPrefix().Bytes(), msg...)
+		msg = append(msg, account.Bytes()...)
+		ok, err := crypto.Verify(signer.Signer.SigningPubKey.Bytes(), hash.Bytes(), msg, signer.Signer.TxnSignature.Bytes())
+		if err != nil {
+			return fmt.Errorf("Signer %s: %s", account, err)
+		}
+		if !ok {
+			return fmt.Errorf("Bad signature for signer: %s", account)
+		}
+	}
+	return nil
+}
+
+// CheckQuorum verifies the signatures of s and returns the total weight of
+// its signers in the signer list, which must reach the list's quorum
+func CheckQuorum(s MultiSignable, list *SignerList) (uint32, error) {
+	if err := CheckMultiSignatures(s); err != nil {
+		return 0, err
+	}
+	weights := make(map[Account]uint32)
+	for _, entry := range list.SignerEntries {
+		if entry.SignerEntry.Account != nil && entry.SignerEntry.SignerWeight != nil {
+			weights[*entry.SignerEntry.Account] = uint32(*entry.SignerEntry.SignerWeight)
+		}
+	}
+	var total uint32
+	for _, signer := range s.GetSigners() {
+		weight, ok := weights[signer.Signer.Account]
+		if !ok {
+			return 0, fmt.Errorf("Signer %s is not in the signer list", signer.Signer.Account)
+		}
+		total += weight
+	}
+	var quorum uint32
+	if list.SignerQuorum != nil {
+		quorum = *list.SignerQuorum
+	}
+	if total < quorum {
+		return total, fmt.Errorf("Signer weight %d is below quorum: %d", total, quorum)
+	}
+	return total, nil
+}
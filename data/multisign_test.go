@@ -0,0 +1,107 @@
+package data
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+func newMultiSignPayment(t *testing.T) *Payment {
+	amount, err := NewAmount("1000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee, err := NewValue("30", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	account, err := NewAccountFromAddress("rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Payment{
+		TxBase: TxBase{TransactionType: PAYMENT, Account: *account, Sequence: 1, Fee: *fee},
+		Amount: *amount,
+	}
+}
+
+func TestMultiSign(t *testing.T) {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sequence uint32
+	signers := []struct {
+		signer  crypto.Signer
+		account Account
+	}{
+		{crypto.NewKeySigner(seed.Key(ECDSA), &sequence), seed.AccountId(ECDSA, &sequence)},
+		{crypto.NewKeySigner(seed.Key(Ed25519), nil), seed.AccountId(Ed25519, nil)},
+	}
+	var copies []MultiSignable
+	for _, s := range signers {
+		payment := newMultiSignPayment(t)
+		if err := AddSigner(payment, s.signer, s.account); err != nil {
+			t.Fatal(err)
+		}
+		copies = append(copies, payment)
+	}
+	payment := newMultiSignPayment(t)
+	if err := CombineSigners(payment, copies...); err != nil {
+		t.Fatal(err)
+	}
+	if len(payment.Signers) != 2 {
+		t.Fatalf("Expected 2 signers got: %d", len(payment.Signers))
+	}
+	if err := CombineSigners(payment, copies[0]); err != nil {
+		t.Fatalf("Combining the same signer twice: %s", err)
+	}
+
+	// Round trip through the binary format
+	_, raw, err := Raw(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := ReadTransaction(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckMultiSignatures(tx.(MultiSignable)); err != nil {
+		t.Fatal(err)
+	}
+
+	one, two := uint16(1), uint16(2)
+	list := &SignerList{SignerEntries: []SignerEntry{
+		{SignerEntry: SignerEntryItem{Account: &signers[0].account, SignerWeight: &one}},
+		{SignerEntry: SignerEntryItem{Account: &signers[1].account, SignerWeight: &two}},
+	}}
+	for _, test := range []struct {
+		quorum uint32
+		err    string
+	}{
+		{3, ""},
+		{4, "Signer weight 3 is below quorum: 4"},
+	} {
+		list.SignerQuorum = &test.quorum
+		weight, err := CheckQuorum(payment, list)
+		switch {
+		case test.err == "" && err != nil:
+			t.Fatal(err)
+		case test.err != "" && (err == nil || err.Error() != test.err):
+			t.Fatalf("Expected error: %s got: %v", test.err, err)
+		case weight != 3:
+			t.Fatalf("Expected weight 3 got: %d", weight)
+		}
+	}
+
+	other := newMultiSignPayment(t)
+	other.Sequence++
+	if err := CombineSigners(other, copies...); err == nil {
+		t.Fatal("Combined signers of a different transaction")
+	}
+	(*payment.Signers[0].Signer.TxnSignature)[10] ^= 1
+	if err := CheckMultiSignatures(payment); err == nil {
+		t.Fatal("Bad signature was not detected")
+	}
+}
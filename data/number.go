@@ -0,0 +1,367 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+)
+
+const (
+	minMantissa int64 = 1000000000000000
+	maxMantissa int64 = 9999999999999999
+	minExponent       = -32768
+	maxExponent       = 32768
+)
+
+// RoundingMode is how a Number rounds the digits it cannot hold
+type RoundingMode uint8
+
+const (
+	RoundToNearest RoundingMode = iota // Ties to even
+	RoundTowardsZero
+	RoundDownward
+	RoundUpward
+)
+
+var roundingModes = [...]string{
+	RoundToNearest:   "ToNearest",
+	RoundTowardsZero: "TowardsZero",
+	RoundDownward:    "Downward",
+	RoundUpward:      "Upward",
+}
+
+func (r RoundingMode) String() string {
+	if int(r) < len(roundingModes) {
+		return roundingModes[r]
+	}
+	return "Unknown"
+}
+
+// Number is the decimal floating point type which rippled uses for AMM
+// calculations. It has a mantissa of 16 digits, and unlike Value a wide
+// exponent, and it rounds as it is told. Each operation takes the
+// RoundingMode which rippled would have set when performing it.
+type Number struct {
+	mantissa int64
+	exponent int
+}
+
+// NewNumber returns mantissa*10^exponent, rounded to nearest
+func NewNumber(mantissa int64, exponent int) (*Number, error) {
+	return normaliseNumber(mantissa < 0, uabs(mantissa), exponent, RoundToNearest)
+}
+
+// Mantissa returns the signed mantissa, which is zero or has 16 digits
+func (n Number) Mantissa() int64 {
+	return n.mantissa
+}
+
+// Exponent returns the exponent, which is meaningless for zero
+func (n Number) Exponent() int {
+	return n.exponent
+}
+
+func (n Number) IsZero() bool {
+	return n.mantissa == 0
+}
+
+func (n Number) IsNegative() bool {
+	return n.mantissa < 0
+}
+
+func (n Number) Negate() *Number {
+	return &Number{-n.mantissa, n.exponent}
+}
+
+func (n Number) String() string {
+	if n.mantissa == 0 {
+		return "0"
+	}
+	return strconv.FormatInt(n.mantissa, 10) + "e" + strconv.Itoa(n.exponent)
+}
+
+// Compare returns -1, 0 or +1 as n is less than, equal to or greater than m
+func (n Number) Compare(m Number) int {
+	switch {
+	case n == m:
+		return 0
+	case n.IsNegative() != m.IsNegative():
+		if n.IsNegative() {
+			return -1
+		}
+		return 1
+	case n.IsZero():
+		if m.IsNegative() {
+			return 1
+		}
+		return -1
+	case m.IsZero():
+		if n.IsNegative() {
+			return -1
+		}
+		return 1
+	}
+	less := n.exponent < m.exponent || (n.exponent == m.exponent && uabs(n.mantissa) < uabs(m.mantissa))
+	if less != n.IsNegative() {
+		return -1
+	}
+	return 1
+}
+
+// Number returns v as a Number, with native values in drips
+func (v Value) Number() (*Number, error) {
+	if v.num > math.MaxInt64 {
+		return nil, fmt.Errorf("Value too large for Number: %s", v.debug())
+	}
+	return normaliseNumber(v.negative, v.num, int(v.offset), RoundToNearest)
+}
+
+// Value returns n as a Value. Native values are whole drips rounded by
+// mode, while non-native values are exact within the range of Value.
+func (n Number) Value(native bool, mode RoundingMode) (*Value, error) {
+	if !native {
+		if n.mantissa == 0 || n.exponent < int(minOffset) {
+			return zeroNonNative.Clone(), nil
+		}
+		if n.exponent > int(maxOffset) {
+			return nil, fmt.Errorf("Number overflow: %s", n)
+		}
+		v := newValue(false, n.mantissa < 0, uabs(n.mantissa), int64(n.exponent))
+		return v, v.canonicalise()
+	}
+	drips, exponent := uabs(n.mantissa), n.exponent
+	var g numberGuard
+	if drips != 0 {
+		g.negative = n.mantissa < 0
+		for ; exponent < 0; exponent++ {
+			g.push(drips % 10)
+			drips /= 10
+		}
+		for ; exponent > 0; exponent-- {
+			if drips > math.MaxInt64/10 {
+				return nil, fmt.Errorf("Number overflow: %s", n)
+			}
+			drips *= 10
+		}
+		if r := g.round(mode); r == 1 || (r == 0 && drips&1 == 1) {
+			drips++
+		}
+	}
+	v := newValue(true, g.negative, drips, 0)
+	return v, v.canonicalise()
+}
+
+// Add returns n+m rounded by mode
+func (n Number) Add(m Number, mode RoundingMode) (*Number, error) {
+	switch {
+	case m.IsZero():
+		return &n, nil
+	case n.IsZero():
+		return &m, nil
+	case n == *m.Negate():
+		return &Number{}, nil
+	}
+	xm, xe, xn := uabs(n.mantissa), n.exponent, n.IsNegative()
+	ym, ye, yn := uabs(m.mantissa), m.exponent, m.IsNegative()
+	var g numberGuard
+	switch {
+	case xe < ye:
+		g.negative = xn
+		for ; xe < ye; xe++ {
+			g.push(xm % 10)
+			xm /= 10
+		}
+	case xe > ye:
+		g.negative = yn
+		for ; xe > ye; ye++ {
+			g.push(ym % 10)
+			ym /= 10
+		}
+	}
+	if xn == yn {
+		xm += ym
+		if xm > uint64(maxMantissa) {
+			g.push(xm % 10)
+			xm /= 10
+			xe++
+		}
+		if r := g.round(mode); r == 1 || (r == 0 && xm&1 == 1) {
+			xm++
+			if xm > uint64(maxMantissa) {
+				xm /= 10
+				xe++
+			}
+		}
+		if xe > maxExponent {
+			return nil, fmt.Errorf("Number overflow: %s+%s", n, m)
+		}
+	} else {
+		if xm > ym {
+			xm -= ym
+		} else {
+			xm, xe, xn = ym-xm, ye, yn
+		}
+		for xm < uint64(minMantissa) {
+			xm = xm*10 - g.pop()
+			xe--
+		}
+		if r := g.round(mode); r == 1 || (r == 0 && xm&1 == 1) {
+			xm--
+			if xm < uint64(minMantissa) {
+				xm *= 10
+				xe--
+			}
+		}
+		if xe < minExponent {
+			return &Number{}, nil
+		}
+	}
+	return signedNumber(xn, xm, xe), nil
+}
+
+// Subtract returns n-m rounded by mode
+func (n Number) Subtract(m Number, mode RoundingMode) (*Number, error) {
+	return n.Add(*m.Negate(), mode)
+}
+
+// Multiply returns n*m rounded by mode
+func (n Number) Multiply(m Number, mode RoundingMode) (*Number, error) {
+	if n.IsZero() || m.IsZero() {
+		return &Number{}, nil
+	}
+	negative := n.IsNegative() != m.IsNegative()
+	hi, lo := bits.Mul64(uabs(n.mantissa), uabs(m.mantissa))
+	exponent := n.exponent + m.exponent
+	g := numberGuard{negative: negative}
+	for hi > 0 || lo > uint64(maxMantissa) {
+		var rem uint64
+		hi, rem = hi/10, hi%10
+		lo, rem = bits.Div64(rem, lo, 10)
+		g.push(rem)
+		exponent++
+	}
+	if r := g.round(mode); r == 1 || (r == 0 && lo&1 == 1) {
+		lo++
+		if lo > uint64(maxMantissa) {
+			lo /= 10
+			exponent++
+		}
+	}
+	if exponent < minExponent {
+		return &Number{}, nil
+	}
+	if exponent > maxExponent {
+		return nil, fmt.Errorf("Number overflow: %s*%s", n, m)
+	}
+	return signedNumber(negative, lo, exponent), nil
+}
+
+// Divide returns n/m. As in rippled the quotient is truncated to 18 digits
+// before being rounded by mode.
+func (n Number) Divide(m Number, mode RoundingMode) (*Number, error) {
+	if m.IsZero() {
+		return nil, fmt.Errorf("Division by zero")
+	}
+	if n.IsZero() {
+		return &Number{}, nil
+	}
+	hi, lo := bits.Mul64(uabs(n.mantissa), tenTo17)
+	quotient, _ := bits.Div64(hi, lo, uabs(m.mantissa))
+	return normaliseNumber(n.IsNegative() != m.IsNegative(), quotient, n.exponent-m.exponent-17, mode)
+}
+
+// normaliseNumber brings mantissa into the range of 16 digits
+func normaliseNumber(negative bool, mantissa uint64, exponent int, mode RoundingMode) (*Number, error) {
+	if mantissa == 0 {
+		return &Number{}, nil
+	}
+	for mantissa < uint64(minMantissa) && exponent > minExponent {
+		mantissa *= 10
+		exponent--
+	}
+	g := numberGuard{negative: negative}
+	for mantissa > uint64(maxMantissa) {
+		if exponent >= maxExponent {
+			return nil, fmt.Errorf("Number overflow: %de%d", mantissa, exponent)
+		}
+		g.push(mantissa % 10)
+		mantissa /= 10
+		exponent++
+	}
+	if exponent < minExponent || mantissa < uint64(minMantissa) {
+		return &Number{}, nil
+	}
+	if r := g.round(mode); r == 1 || (r == 0 && mantissa&1 == 1) {
+		mantissa++
+		if mantissa > uint64(maxMantissa) {
+			mantissa /= 10
+			exponent++
+		}
+	}
+	if exponent > maxExponent {
+		return nil, fmt.Errorf("Number overflow: %de%d", mantissa, exponent)
+	}
+	return signedNumber(negative, mantissa, exponent), nil
+}
+
+func signedNumber(negative bool, mantissa uint64, exponent int) *Number {
+	if negative {
+		return &Number{-int64(mantissa), exponent}
+	}
+	return &Number{int64(mantissa), exponent}
+}
+
+func uabs(n int64) uint64 {
+	if n < 0 {
+		return uint64(-n)
+	}
+	return uint64(n)
+}
+
+// numberGuard holds up to 16 digits which have been shifted off the end of
+// a mantissa, most recent first, and whether any digit was lost beyond them
+type numberGuard struct {
+	digits   uint64
+	lost     bool
+	negative bool
+}
+
+func (g *numberGuard) push(d uint64) {
+	g.lost = g.lost || g.digits&0xF != 0
+	g.digits >>= 4
+	g.digits |= (d & 0xF) << 60
+}
+
+func (g *numberGuard) pop() uint64 {
+	d := g.digits >> 60
+	g.digits <<= 4
+	return d
+}
+
+// round returns 1 when the mantissa should move away from zero, -1 when it
+// should stay, and 0 for a tie which is broken to even
+func (g *numberGuard) round(mode RoundingMode) int {
+	const half = 0x5000000000000000
+	switch mode {
+	case RoundToNearest:
+		switch {
+		case g.digits > half:
+			return 1
+		case g.digits < half:
+			return -1
+		case g.lost:
+			return 1
+		}
+		return 0
+	case RoundDownward:
+		if g.negative && (g.digits > 0 || g.lost) {
+			return 1
+		}
+	case RoundUpward:
+		if !g.negative && (g.digits > 0 || g.lost) {
+			return 1
+		}
+	}
+	return -1
+}
@@ -0,0 +1,151 @@
+package data
+
+import (
+	"fmt"
+	"testing"
+)
+
+func newNumber(t *testing.T, mantissa int64, exponent int) Number {
+	t.Helper()
+	n, err := NewNumber(mantissa, exponent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *n
+}
+
+type numberOp func(Number, Number, RoundingMode) (*Number, error)
+
+// Vectors from rippled's Number_test.cpp
+var numberTests = []struct {
+	name     string
+	op       numberOp
+	mode     RoundingMode
+	x, y     [2]int64
+	expected [2]int64
+}{
+	{"Add", Number.Add, RoundToNearest, [2]int64{1000000000000000, -15}, [2]int64{6555555555555555, -29}, [2]int64{1000000000000066, -15}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{-1000000000000000, -15}, [2]int64{-6555555555555555, -29}, [2]int64{-1000000000000066, -15}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{-1000000000000000, -15}, [2]int64{6555555555555555, -29}, [2]int64{-9999999999999344, -16}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{-6555555555555555, -29}, [2]int64{1000000000000000, -15}, [2]int64{9999999999999344, -16}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{0, 0}, [2]int64{5, 0}, [2]int64{5, 0}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{5555555555555555, -32768}, [2]int64{-5555555555555554, -32768}, [2]int64{0, 0}},
+	{"Add", Number.Add, RoundToNearest, [2]int64{-9999999999999999, -31}, [2]int64{1000000000000000, -15}, [2]int64{9999999999999990, -16}},
+
+	{"Subtract", Number.Subtract, RoundToNearest, [2]int64{1000000000000000, -15}, [2]int64{6555555555555555, -29}, [2]int64{9999999999999344, -16}},
+	{"Subtract", Number.Subtract, RoundToNearest, [2]int64{6555555555555555, -29}, [2]int64{1000000000000000, -15}, [2]int64{-9999999999999344, -16}},
+	{"Subtract", Number.Subtract, RoundToNearest, [2]int64{1000000000000000, -15}, [2]int64{1000000000000000, -15}, [2]int64{0, 0}},
+	{"Subtract", Number.Subtract, RoundToNearest, [2]int64{1000000000000000, -15}, [2]int64{1000000000000001, -15}, [2]int64{-1000000000000000, -30}},
+	{"Subtract", Number.Subtract, RoundToNearest, [2]int64{1000000000000001, -15}, [2]int64{1000000000000000, -15}, [2]int64{1000000000000000, -30}},
+
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{7, 0}, [2]int64{8, 0}, [2]int64{56, 0}},
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{2000000000000000, -15}},
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{-1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{-2000000000000000, -15}},
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{-1414213562373095, -15}, [2]int64{-1414213562373095, -15}, [2]int64{2000000000000000, -15}},
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{3214285714285706, -15}, [2]int64{3111111111111119, -15}, [2]int64{1000000000000000, -14}},
+	{"Multiply", Number.Multiply, RoundToNearest, [2]int64{1000000000000000, -32768}, [2]int64{1000000000000000, -32768}, [2]int64{0, 0}},
+	{"Multiply", Number.Multiply, RoundTowardsZero, [2]int64{1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{1999999999999999, -15}},
+	{"Multiply", Number.Multiply, RoundTowardsZero, [2]int64{-1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{-1999999999999999, -15}},
+	{"Multiply", Number.Multiply, RoundTowardsZero, [2]int64{3214285714285706, -15}, [2]int64{3111111111111119, -15}, [2]int64{9999999999999999, -15}},
+	{"Multiply", Number.Multiply, RoundDownward, [2]int64{1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{1999999999999999, -15}},
+	{"Multiply", Number.Multiply, RoundDownward, [2]int64{-1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{-2000000000000000, -15}},
+	{"Multiply", Number.Multiply, RoundUpward, [2]int64{1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{2000000000000000, -15}},
+	{"Multiply", Number.Multiply, RoundUpward, [2]int64{-1414213562373095, -15}, [2]int64{1414213562373095, -15}, [2]int64{-1999999999999999, -15}},
+
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{1, 0}, [2]int64{2, 0}, [2]int64{5, -1}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{1, 0}, [2]int64{10, 0}, [2]int64{1, -1}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{1, 0}, [2]int64{-10, 0}, [2]int64{-1, -1}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{0, 0}, [2]int64{100, 0}, [2]int64{0, 0}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{1414213562373095, -10}, [2]int64{1414213562373095, -10}, [2]int64{1, 0}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{9999999999999999, 0}, [2]int64{1000000000000000, 0}, [2]int64{9999999999999999, -15}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{2, 0}, [2]int64{3, 0}, [2]int64{6666666666666667, -16}},
+	{"Divide", Number.Divide, RoundToNearest, [2]int64{-2, 0}, [2]int64{3, 0}, [2]int64{-6666666666666667, -16}},
+	{"Divide", Number.Divide, RoundTowardsZero, [2]int64{2, 0}, [2]int64{3, 0}, [2]int64{6666666666666666, -16}},
+	{"Divide", Number.Divide, RoundTowardsZero, [2]int64{-2, 0}, [2]int64{3, 0}, [2]int64{-6666666666666666, -16}},
+	{"Divide", Number.Divide, RoundDownward, [2]int64{2, 0}, [2]int64{3, 0}, [2]int64{6666666666666666, -16}},
+	{"Divide", Number.Divide, RoundDownward, [2]int64{-2, 0}, [2]int64{3, 0}, [2]int64{-6666666666666667, -16}},
+	{"Divide", Number.Divide, RoundUpward, [2]int64{2, 0}, [2]int64{3, 0}, [2]int64{6666666666666667, -16}},
+	{"Divide", Number.Divide, RoundUpward, [2]int64{-2, 0}, [2]int64{3, 0}, [2]int64{-6666666666666666, -16}},
+}
+
+func TestNumber(t *testing.T) {
+	for _, test := range numberTests {
+		x, y := newNumber(t, test.x[0], int(test.x[1])), newNumber(t, test.y[0], int(test.y[1]))
+		expected := newNumber(t, test.expected[0], int(test.expected[1]))
+		result, err := test.op(x, y, test.mode)
+		if err != nil {
+			t.Fatalf("%s %s: %s", test.name, test.mode, err)
+		}
+		if *result != expected {
+			t.Errorf("%s %s %s %s: Expected %s Got %s", test.name, test.mode, x, y, expected, result)
+		}
+	}
+	if _, err := newNumber(t, 1, 0).Divide(Number{}, RoundToNearest); err == nil {
+		t.Error("Expected division by zero to fail")
+	}
+}
+
+// Vectors from the conversion of Number to XRPAmount in rippled's
+// Number_test.cpp
+func TestNumberDrips(t *testing.T) {
+	for _, test := range []struct {
+		mode     RoundingMode
+		n        [2]int64
+		expected int64
+	}{
+		{RoundToNearest, [2]int64{9999999999999999, 1}, 99999999999999990},
+		{RoundToNearest, [2]int64{15, -1}, 2},
+		{RoundToNearest, [2]int64{14, -1}, 1},
+		{RoundToNearest, [2]int64{16, -1}, 2},
+		{RoundToNearest, [2]int64{25, -1}, 2},
+		{RoundToNearest, [2]int64{6, -1}, 1},
+		{RoundToNearest, [2]int64{5, -1}, 0},
+		{RoundToNearest, [2]int64{4, -1}, 0},
+		{RoundToNearest, [2]int64{-15, -1}, -2},
+		{RoundToNearest, [2]int64{-25, -1}, -2},
+		{RoundToNearest, [2]int64{-5, -1}, 0},
+		{RoundTowardsZero, [2]int64{15, -1}, 1},
+		{RoundTowardsZero, [2]int64{-15, -1}, -1},
+		{RoundTowardsZero, [2]int64{6, -1}, 0},
+		{RoundDownward, [2]int64{15, -1}, 1},
+		{RoundDownward, [2]int64{-15, -1}, -2},
+		{RoundDownward, [2]int64{-4, -1}, -1},
+		{RoundUpward, [2]int64{15, -1}, 2},
+		{RoundUpward, [2]int64{4, -1}, 1},
+		{RoundUpward, [2]int64{-15, -1}, -1},
+	} {
+		n := newNumber(t, test.n[0], int(test.n[1]))
+		v, err := n.Value(true, test.mode)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// NewNativeValue only accepts positive drips
+		expected, err := NewNativeValue(int64(uabs(test.expected)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.expected < 0 {
+			expected = expected.Negate()
+		}
+		if !v.Equals(*expected) {
+			t.Errorf("%s %s: Expected %d drips Got %s", n, test.mode, test.expected, v)
+		}
+	}
+}
+
+func TestValueNumber(t *testing.T) {
+	for _, s := range []string{"0", "1.23", "-0.000000000123", "9999999999999999e80", "1e-81"} {
+		v := valueCheck(s)
+		n, err := v.Number()
+		if err != nil {
+			t.Fatal(err)
+		}
+		back, err := n.Value(false, RoundToNearest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(back) != fmt.Sprint(v) {
+			t.Errorf("Expected %s to survive a Number got %s", v, back)
+		}
+	}
+}
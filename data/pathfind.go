@@ -0,0 +1,629 @@
+package data
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// maxPaths is the most paths a Payment may carry
+const maxPaths = 6
+
+// pathNode is an account holding a currency, or XRP when both are zero
+type pathNode struct {
+	account  Account
+	currency Currency
+}
+
+// pathBook is the liquidity which exchanges one asset for another
+type pathBook struct {
+	in, out Issue
+	offers  []*Offer // Best first
+	amm     *AMM
+}
+
+// pathStep moves value from one pathNode to another, either by rippling
+// along a trust line or by exchanging through a book
+type pathStep struct {
+	from, to pathNode
+	line     *RippleState
+	book     *pathBook
+}
+
+type lineKey struct {
+	a, b     Account
+	currency Currency
+}
+
+// PathFinder searches a snapshot of ledger state for the paths along which
+// a Payment can deliver an amount
+type PathFinder struct {
+	// MaxSteps is the most rippling and exchange steps in a path
+	MaxSteps int
+	accounts map[Account]*AccountRoot
+	lines    map[pathNode][]*RippleState
+	pairs    map[lineKey]*RippleState
+	books    map[Issue][]*pathBook
+}
+
+// NewPathFinder indexes the AccountRoot, RippleState, Offer and AMM entries
+// and ignores the rest. The balances of AMM pools are read from the entries
+// of their accounts, and Offers are funded by the balances of their owners,
+// so an Offer whose owner is absent from entries is never taken.
+func NewPathFinder(entries LedgerEntrySlice) (*PathFinder, error) {
+	pf := &PathFinder{
+		MaxSteps: 6,
+		accounts: make(map[Account]*AccountRoot),
+		lines:    make(map[pathNode][]*RippleState),
+		pairs:    make(map[lineKey]*RippleState),
+		books:    make(map[Issue][]*pathBook),
+	}
+	var (
+		offers []*Offer
+		amms   []*AMM
+	)
+	for _, le := range entries {
+		switch v := le.(type) {
+		case *AccountRoot:
+			if v.Account == nil {
+				return nil, fmt.Errorf("AccountRoot has no Account")
+			}
+			pf.accounts[*v.Account] = v
+		case *RippleState:
+			if v.LowLimit == nil || v.HighLimit == nil || v.Balance == nil {
+				return nil, fmt.Errorf("RippleState is incomplete")
+			}
+			low, high, currency := v.LowLimit.Issuer, v.HighLimit.Issuer, v.Balance.Currency
+			pf.lines[pathNode{low, currency}] = append(pf.lines[pathNode{low, currency}], v)
+			pf.lines[pathNode{high, currency}] = append(pf.lines[pathNode{high, currency}], v)
+			pf.pairs[lineKey{low, high, currency}] = v
+			pf.pairs[lineKey{high, low, currency}] = v
+		case *Offer:
+			if v.Account == nil || v.TakerPays == nil || v.TakerGets == nil {
+				return nil, fmt.Errorf("Offer is incomplete")
+			}
+			if !v.TakerPays.IsZero() && !v.TakerGets.IsZero() {
+				offers = append(offers, v)
+			}
+		case *AMM:
+			if v.Account == nil || v.Asset == nil || v.Asset2 == nil {
+				return nil, fmt.Errorf("AMM is incomplete")
+			}
+			amms = append(amms, v)
+		}
+	}
+	for _, o := range offers {
+		b := pf.book(issueOf(o.TakerPays), issueOf(o.TakerGets))
+		b.offers = append(b.offers, o)
+	}
+	for _, amm := range amms {
+		pf.book(*amm.Asset, *amm.Asset2).amm = amm
+		pf.book(*amm.Asset2, *amm.Asset).amm = amm
+	}
+	for _, books := range pf.books {
+		for _, b := range books {
+			sort.SliceStable(b.offers, func(i, j int) bool {
+				return price(b.offers[i]) < price(b.offers[j])
+			})
+		}
+	}
+	return pf, nil
+}
+
+// book returns the book exchanging in for out, adding it if necessary
+func (pf *PathFinder) book(in, out Issue) *pathBook {
+	for _, b := range pf.books[in] {
+		if b.out == out {
+			return b
+		}
+	}
+	b := &pathBook{in: in, out: out}
+	pf.books[in] = append(pf.books[in], b)
+	return b
+}
+
+// FoundPath is a path along which the whole of an amount can be delivered
+type FoundPath struct {
+	Path Path
+	// Cost is what the source sends, including transfer fees and the
+	// qualities of trust lines
+	Cost *Amount
+}
+
+func (f FoundPath) String() string {
+	if len(f.Path) == 0 {
+		return fmt.Sprintf("Cost: %s Path: default", f.Cost)
+	}
+	return fmt.Sprintf("Cost: %s Path: %s", f.Cost, f.Path)
+}
+
+// FoundPathSlice is sorted by Cost, cheapest first
+type FoundPathSlice []FoundPath
+
+// PathSet returns the cheapest paths which a Payment may carry, leaving out
+// the default path, which every Payment tries anyway
+func (s FoundPathSlice) PathSet() PathSet {
+	var paths PathSet
+	for _, f := range s {
+		if len(f.Path) > 0 && len(paths) < maxPaths {
+			paths = append(paths, f.Path)
+		}
+	}
+	return paths
+}
+
+// Find returns the paths of no more than MaxSteps which can each deliver
+// amount to destination from the sourceCurrency of source. When the issuer
+// of amount is destination, any issuer which destination trusts will do.
+//
+// A line can carry its balance plus the limit of the receiving account.
+// Value is not rippled through an account which has set NoRipple on both
+// lines, nor through an issuer with a global freeze, and is only sent along
+// a line frozen by the receiver when the receiver is destination. An issuer
+// charges its TransferRate when value passes through it from one holder or
+// book to another, which is paid by the sender rather than the owners of
+// offers, and the QualityIn of receivers and QualityOut of senders are
+// applied. Offers are taken best first, interleaved with any AMM pool in the
+// same book, which is priced as a constant product less its TradingFee.
+//
+// Costs are estimated with floating point, and each path is costed on its
+// own, though a Payment would share the liquidity between its paths.
+//
+// The search is breadth first. Of the partial paths which reach the same
+// account and currency in the same number of steps, only the one with the
+// lowest price per unit is extended, the price being estimated from the
+// qualities and transfer fees along it and the best rate of each book. So
+// no more than MaxSteps partial paths are extended through each node, and
+// the paths found are not every path, but the cheapest by that estimate.
+func (pf *PathFinder) Find(source Account, sourceCurrency Currency, destination Account, amount Amount) (FoundPathSlice, error) {
+	if amount.IsZero() || amount.IsNegative() {
+		return nil, fmt.Errorf("Amount must be positive")
+	}
+	start := pathNode{source, sourceCurrency}
+	if sourceCurrency.IsNative() {
+		start = pathNode{}
+	}
+	goal := func(n pathNode, steps []pathStep) bool {
+		if amount.IsNative() {
+			return n == pathNode{}
+		}
+		if n.account != destination || n.currency != amount.Currency {
+			return false
+		}
+		if amount.Issuer == destination || len(steps) == 0 {
+			return true
+		}
+		last := steps[len(steps)-1]
+		return last.line != nil && last.from.account == amount.Issuer
+	}
+	var found FoundPathSlice
+	reached := func(steps []pathStep) error {
+		f, err := pf.cost(steps, source, sourceCurrency, destination, amount)
+		if f != nil {
+			found = append(found, *f)
+		}
+		return err
+	}
+	if goal(start, nil) {
+		if err := reached(nil); err != nil {
+			return nil, err
+		}
+		return found, nil
+	}
+	frontier := []pathPartial{{to: start, price: 1}}
+	for depth := 0; depth < pf.MaxSteps && len(frontier) > 0; depth++ {
+		var (
+			next []pathPartial
+			best = map[pathNode]int{}
+		)
+		for _, partial := range frontier {
+			for _, step := range pf.steps(partial.to, partial.steps, destination) {
+				if step.to == start || partial.visits(step.to) {
+					continue
+				}
+				// Copied, as partials share their steps
+				steps := append(append([]pathStep(nil), partial.steps...), step)
+				if goal(step.to, steps) {
+					if err := reached(steps); err != nil {
+						return nil, err
+					}
+					continue
+				}
+				extended := pathPartial{
+					to:    step.to,
+					steps: steps,
+					price: partial.price * pf.estimate(partial.steps, step, source),
+				}
+				if i, ok := best[step.to]; ok {
+					if extended.price < next[i].price {
+						next[i] = extended
+					}
+					continue
+				}
+				best[step.to] = len(next)
+				next = append(next, extended)
+			}
+		}
+		frontier = next
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if cmp := found[i].Cost.Compare(*found[j].Cost.Value); cmp != 0 {
+			return cmp < 0
+		}
+		return len(found[i].Path) < len(found[j].Path)
+	})
+	return found, nil
+}
+
+// pathPartial is the start of a path which has not yet reached its goal
+type pathPartial struct {
+	to    pathNode
+	steps []pathStep
+	// price is the estimated cost to the source of each unit arriving at to
+	price float64
+}
+
+// visits returns whether n is reached by a step of p
+func (p pathPartial) visits(n pathNode) bool {
+	for _, step := range p.steps {
+		if step.to == n {
+			return true
+		}
+	}
+	return false
+}
+
+// estimate returns roughly what the sender of step must send for each unit
+// which arrives, following the steps already taken. Liquidity is ignored,
+// and a book is priced at its best offer or the spot price of its pool.
+func (pf *PathFinder) estimate(taken []pathStep, step pathStep, source Account) float64 {
+	unit := math.Inf(1)
+	if step.line != nil {
+		unit = pf.quality(step, source)
+	} else {
+		if len(step.book.offers) > 0 {
+			unit = price(step.book.offers[0])
+		}
+		if x, y, ok := pf.pool(step.book, &pathLiquidity{}); ok {
+			unit = math.Min(unit, x/(y*(1-step.book.fee())))
+		}
+	}
+	if len(taken) > 0 && pf.charged(taken[len(taken)-1], step) {
+		unit *= pf.rate(step.from.account)
+	}
+	return unit
+}
+
+// steps returns the steps which may follow the steps already taken to n
+func (pf *PathFinder) steps(n pathNode, taken []pathStep, destination Account) []pathStep {
+	var next []pathStep
+	in := Issue{}
+	if !n.currency.IsNative() {
+		in = Issue{Currency: n.currency, Issuer: n.account}
+		var previous *RippleState
+		if len(taken) > 0 {
+			previous = taken[len(taken)-1].line
+		}
+		for _, line := range pf.lines[n] {
+			peer := counterparty(line, n.account)
+			switch {
+			case lineBalance(line, n.account)+lineLimit(line, peer) <= 0:
+				continue
+			case peer != destination && (lineFlag(line, peer, LsLowFreeze, LsHighFreeze) || pf.globalFreeze(peer)):
+				continue
+			case previous != nil && lineFlag(previous, n.account, LsLowNoRipple, LsHighNoRipple) && lineFlag(line, n.account, LsLowNoRipple, LsHighNoRipple):
+				continue
+			}
+			next = append(next, pathStep{from: n, to: pathNode{peer, n.currency}, line: line})
+		}
+	}
+	for _, b := range pf.books[in] {
+		if pf.globalFreeze(b.in.Issuer) || pf.globalFreeze(b.out.Issuer) {
+			continue
+		}
+		next = append(next, pathStep{from: n, to: pathNode{b.out.Issuer, b.out.Currency}, book: b})
+	}
+	return next
+}
+
+// pathLiquidity is what remains of the liquidity of a snapshot while a
+// path is costed
+type pathLiquidity struct {
+	funds map[lineKey]float64
+	taken map[*Offer]float64
+	pools map[*AMM][2]float64
+}
+
+// cost works backwards along steps from the amount which destination is
+// to receive, returning nil when the liquidity runs out
+func (pf *PathFinder) cost(steps []pathStep, source Account, sourceCurrency Currency, destination Account, amount Amount) (*FoundPath, error) {
+	var (
+		want = amount.Float()
+		path Path
+		l    = &pathLiquidity{
+			funds: make(map[lineKey]float64),
+			taken: make(map[*Offer]float64),
+			pools: make(map[*AMM][2]float64),
+		}
+	)
+	for i := len(steps) - 1; i >= 0; i-- {
+		step := steps[i]
+		if step.line != nil {
+			want = pf.ripple(step, want, source)
+		} else {
+			want = pf.exchange(step.book, want, l)
+		}
+		if i > 0 && pf.charged(steps[i-1], step) {
+			want *= pf.rate(step.from.account)
+		}
+		if math.IsInf(want, 0) || math.IsNaN(want) {
+			return nil, nil
+		}
+	}
+	for i, step := range steps {
+		// Copied, as steps is reused by the search
+		to := step.to
+		switch {
+		case step.book != nil && to.currency.IsNative():
+			path = append(path, PathElem{Currency: &to.currency})
+		case step.book != nil:
+			path = append(path, PathElem{Currency: &to.currency, Issuer: &to.account})
+		case i < len(steps)-1 || to.account != destination:
+			path = append(path, PathElem{Account: &to.account})
+		}
+	}
+	// Rippling through the issuer of amount is the default path
+	if len(path) == 1 && path[0].Account != nil && *path[0].Account == amount.Issuer {
+		path = nil
+	}
+	var (
+		cost *Value
+		err  error
+	)
+	if sourceCurrency.IsNative() {
+		cost, err = NewNativeValue(int64(math.Ceil(want*1e6 - 1e-6)))
+		return &FoundPath{Path: path, Cost: newAmount(cost, zeroCurrency, zeroAccount)}, err
+	}
+	if cost, err = NewValue(strconv.FormatFloat(want, 'g', 15, 64), false); err != nil {
+		return nil, err
+	}
+	return &FoundPath{Path: path, Cost: newAmount(cost, sourceCurrency, source)}, nil
+}
+
+// ripple returns what the sender of step must send for the receiver to be
+// credited with out, or infinity when the line cannot carry it
+func (pf *PathFinder) ripple(step pathStep, out float64, source Account) float64 {
+	in := out * pf.quality(step, source)
+	if in > lineBalance(step.line, step.from.account)+lineLimit(step.line, step.to.account) {
+		return math.Inf(1)
+	}
+	return in
+}
+
+// quality returns what the sender of step sends for each unit credited to
+// the receiver, from the QualityIn of the receiver and QualityOut of the
+// sender
+func (pf *PathFinder) quality(step pathStep, source Account) float64 {
+	sender, receiver := step.from.account, step.to.account
+	q := 1.0
+	if in := lineQuality(step.line, receiver, true); in != 0 {
+		q = q * 1e9 / float64(in)
+	}
+	if out := lineQuality(step.line, sender, false); out != 0 && sender != source {
+		q = q * float64(out) / 1e9
+	}
+	return q
+}
+
+// charged returns whether the issuer between two steps charges its
+// TransferRate, which is when value arrives by a holder redeeming and leaves
+// by the issuer issuing. As in rippled's payment engine, the owners of offers
+// do not pay the fee, so a book counts as redeeming what it delivers and as
+// issuing what it takes.
+func (pf *PathFinder) charged(previous, step pathStep) bool {
+	if previous.line != nil && lineBalance(previous.line, previous.from.account) <= 0 {
+		return false
+	}
+	return step.book != nil || lineBalance(step.line, step.from.account) <= 0
+}
+
+// exchange returns what must be paid into b to get out, or infinity when
+// there is not enough liquidity
+func (pf *PathFinder) exchange(b *pathBook, out float64, l *pathLiquidity) float64 {
+	var (
+		in    float64
+		total = out
+	)
+	for _, o := range b.offers {
+		if out <= 0 {
+			break
+		}
+		p := price(o)
+		got, paid := pf.swap(b, math.Max(0, math.Min(out, pf.poolDepth(b, p, l))), l)
+		in, out = in+paid, out-got
+		if out <= 0 {
+			break
+		}
+		available := o.TakerGets.Float() - l.taken[o]
+		owner, issuer := *o.Account, b.out.Issuer
+		if b.out.Currency.IsNative() || owner != issuer {
+			key := lineKey{owner, issuer, b.out.Currency}
+			funds, ok := l.funds[key]
+			if !ok {
+				funds = pf.funds(owner, b.out)
+			}
+			available = math.Min(available, funds)
+			l.funds[key] = funds - math.Min(available, out)
+		}
+		if available <= 0 {
+			continue
+		}
+		got = math.Min(available, out)
+		l.taken[o] += got
+		in, out = in+got*p, out-got
+	}
+	if out > 0 {
+		got, paid := pf.swap(b, out, l)
+		in, out = in+paid, out-got
+	}
+	if out > 1e-12*total {
+		return math.Inf(1)
+	}
+	return in
+}
+
+// pool returns the balances of the AMM of b in its input and output assets
+func (pf *PathFinder) pool(b *pathBook, l *pathLiquidity) (float64, float64, bool) {
+	if b.amm == nil {
+		return 0, 0, false
+	}
+	if balances, ok := l.pools[b.amm]; ok {
+		if *b.amm.Asset == b.in {
+			return balances[0], balances[1], true
+		}
+		return balances[1], balances[0], true
+	}
+	x, y := pf.funds(*b.amm.Account, b.in), pf.funds(*b.amm.Account, b.out)
+	if x <= 0 || y <= 0 || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return 0, 0, false
+	}
+	return x, y, true
+}
+
+// fee returns the TradingFee of the AMM of b as a fraction
+func (b *pathBook) fee() float64 {
+	if b.amm.TradingFee == nil {
+		return 0
+	}
+	return float64(*b.amm.TradingFee) / 100000
+}
+
+// poolDepth returns how much the AMM of b gives before its price rises to p
+func (pf *PathFinder) poolDepth(b *pathBook, p float64, l *pathLiquidity) float64 {
+	x, y, ok := pf.pool(b, l)
+	if !ok {
+		return 0
+	}
+	return y - math.Sqrt(x*y/(p*(1-b.fee())))
+}
+
+// swap takes out from the AMM of b, returning what it got and paid
+func (pf *PathFinder) swap(b *pathBook, out float64, l *pathLiquidity) (float64, float64) {
+	x, y, ok := pf.pool(b, l)
+	if !ok || out <= 0 || out >= y {
+		return 0, 0
+	}
+	in := x * out / ((y - out) * (1 - b.fee()))
+	x, y = x+in, y-out
+	if *b.amm.Asset == b.in {
+		l.pools[b.amm] = [2]float64{x, y}
+	} else {
+		l.pools[b.amm] = [2]float64{y, x}
+	}
+	return out, in
+}
+
+// funds returns the balance of account in asset, which is unlimited for
+// its issuer and nothing when frozen
+func (pf *PathFinder) funds(account Account, asset Issue) float64 {
+	if asset.Currency.IsNative() {
+		if root, ok := pf.accounts[account]; ok && root.Balance != nil {
+			return root.Balance.Float()
+		}
+		return 0
+	}
+	if account == asset.Issuer {
+		return math.Inf(1)
+	}
+	line, ok := pf.pairs[lineKey{account, asset.Issuer, asset.Currency}]
+	if !ok || pf.globalFreeze(asset.Issuer) || lineFlag(line, asset.Issuer, LsLowFreeze, LsHighFreeze) {
+		return 0
+	}
+	return math.Max(0, lineBalance(line, account))
+}
+
+// rate returns the TransferRate of issuer as a multiplier
+func (pf *PathFinder) rate(issuer Account) float64 {
+	root, ok := pf.accounts[issuer]
+	if !ok || root.TransferRate == nil || *root.TransferRate == 0 {
+		return 1
+	}
+	return float64(*root.TransferRate) / 1e9
+}
+
+func (pf *PathFinder) globalFreeze(account Account) bool {
+	root, ok := pf.accounts[account]
+	return ok && root.Flags != nil && *root.Flags&LsGlobalFreeze != 0
+}
+
+func issueOf(a *Amount) Issue {
+	if a.IsNative() {
+		return Issue{}
+	}
+	return Issue{Currency: a.Currency, Issuer: a.Issuer}
+}
+
+// price is what the taker of o pays for each unit it gets
+func price(o *Offer) float64 {
+	return o.TakerPays.Float() / o.TakerGets.Float()
+}
+
+func isLow(line *RippleState, account Account) bool {
+	return line.LowLimit.Issuer == account
+}
+
+func counterparty(line *RippleState, account Account) Account {
+	if isLow(line, account) {
+		return line.HighLimit.Issuer
+	}
+	return line.LowLimit.Issuer
+}
+
+// lineBalance returns the balance of line as seen by account, which is
+// positive when the counterparty owes account
+func lineBalance(line *RippleState, account Account) float64 {
+	if isLow(line, account) {
+		return line.Balance.Float()
+	}
+	return -line.Balance.Float()
+}
+
+// lineLimit returns how much account trusts its counterparty on line
+func lineLimit(line *RippleState, account Account) float64 {
+	if isLow(line, account) {
+		return line.LowLimit.Float()
+	}
+	return line.HighLimit.Float()
+}
+
+// lineFlag returns whether account has set its side of a flag on line
+func lineFlag(line *RippleState, account Account, low, high LedgerEntryFlag) bool {
+	if line.Flags == nil {
+		return false
+	}
+	if isLow(line, account) {
+		return *line.Flags&low != 0
+	}
+	return *line.Flags&high != 0
+}
+
+// lineQuality returns the QualityIn or QualityOut of account on line, in
+// billionths, where zero is none
+func lineQuality(line *RippleState, account Account, in bool) uint32 {
+	var q *uint32
+	switch low := isLow(line, account); {
+	case low && in:
+		q = line.LowQualityIn
+	case low:
+		q = line.LowQualityOut
+	case in:
+		q = line.HighQualityIn
+	default:
+		q = line.HighQualityOut
+	}
+	if q == nil {
+		return 0
+	}
+	return *q
+}
@@ -0,0 +1,210 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const (
+	pathSource      = "r3kmLJN5D28dHuH8vZNUZpMC43pEHpaocV"
+	pathDestination = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+	pathGateway     = "rnziParaNb8nsU4aruQdwYE3j5jUcqjzFm"
+	pathGateway2    = "rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj"
+	pathMaker       = "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2"
+	pathAMM         = "rGgj3GurcrAqgBXGVoS9wvQG3Hjkj5oCbj"
+)
+
+var pathNames = strings.NewReplacer(
+	"SOURCE", pathSource, "DESTINATION", pathDestination, "GATEWAY2", pathGateway2,
+	"GATEWAY", pathGateway, "MAKER", pathMaker, "POOL", pathAMM,
+)
+
+// A gateway with a 1% fee, another with 0.2%, an offer of 10 USD at 10 XRP
+// each and a pool of 1000 XRP and 100 USD with a 1% trading fee
+const pathEntries = `[
+	{"LedgerEntryType": "AccountRoot", "index": "0000000000000000000000000000000000000000000000000000000000000001",
+		"Account": "GATEWAY", "Balance": "1000000000", "Flags": 0, "TransferRate": 1010000000},
+	{"LedgerEntryType": "AccountRoot", "index": "0000000000000000000000000000000000000000000000000000000000000002",
+		"Account": "GATEWAY2", "Balance": "1000000000", "Flags": 0, "TransferRate": 1002000000},
+	{"LedgerEntryType": "AccountRoot", "index": "0000000000000000000000000000000000000000000000000000000000000003",
+		"Account": "MAKER", "Balance": "1000000000", "Flags": 0},
+	{"LedgerEntryType": "AccountRoot", "index": "0000000000000000000000000000000000000000000000000000000000000004",
+		"Account": "POOL", "Balance": "1000000000", "Flags": 0,
+		"AMMID": "0000000000000000000000000000000000000000000000000000000000000005"},
+	{"LedgerEntryType": "AMM", "index": "0000000000000000000000000000000000000000000000000000000000000005",
+		"Account": "POOL", "TradingFee": 1000,
+		"Asset": {"currency": "XRP"}, "Asset2": {"currency": "USD", "issuer": "GATEWAY"}},
+	{"LedgerEntryType": "Offer", "index": "0000000000000000000000000000000000000000000000000000000000000006",
+		"Account": "MAKER", "Sequence": 1, "Flags": 0,
+		"TakerPays": "100000000", "TakerGets": {"currency": "USD", "issuer": "GATEWAY", "value": "10"}}
+]`
+
+// newLine returns a USD RippleState on which holder trusts issuer for 1000
+// and holds balance, with flags given for the low side of each account
+func newLine(t *testing.T, holder, issuer, balance string, holderFlags, issuerFlags LedgerEntryFlag) *RippleState {
+	t.Helper()
+	h, i := pathAccount(t, holder), pathAccount(t, issuer)
+	amount := func(s string) *Amount {
+		a, err := NewAmount(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	flags := holderFlags | issuerFlags<<1
+	line := &RippleState{
+		leBase:    leBase{LedgerEntryType: RIPPLE_STATE},
+		Flags:     &flags,
+		LowLimit:  amount("1000/USD/" + holder),
+		HighLimit: amount("0/USD/" + issuer),
+		Balance:   amount(balance + "/USD/rrrrrrrrrrrrrrrrrrrrBZbvji"),
+	}
+	if bytes.Compare(h[:], i[:]) > 0 {
+		flags = holderFlags<<1 | issuerFlags
+		line.LowLimit, line.HighLimit = line.HighLimit, line.LowLimit
+		line.Balance = line.Balance.Negate()
+	}
+	return line
+}
+
+func pathAccount(t *testing.T, address string) Account {
+	t.Helper()
+	account, err := NewAccountFromAddress(address)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *account
+}
+
+func checkPaths(t *testing.T, name string, pf *PathFinder, source, currency, amount string, want ...string) {
+	t.Helper()
+	c, err := NewCurrency(currency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := NewAmount(pathNames.Replace(amount))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := pf.Find(pathAccount(t, source), c, pathAccount(t, pathDestination), *a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, f := range found {
+		got = append(got, f.String())
+	}
+	expected := pathNames.Replace(strings.Join(want, "\n"))
+	if strings.Join(got, "\n") != expected {
+		t.Errorf("%s: Expected:\n%s\nGot:\n%s", name, expected, strings.Join(got, "\n"))
+	}
+}
+
+func TestFind(t *testing.T) {
+	newPathFinder := func(lines ...*RippleState) *PathFinder {
+		var entries LedgerEntrySlice
+		if err := json.Unmarshal([]byte(pathNames.Replace(pathEntries)), &entries); err != nil {
+			t.Fatal(err)
+		}
+		for _, line := range lines {
+			entries = append(entries, line)
+		}
+		pf, err := NewPathFinder(entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pf
+	}
+	lines := func(source, gateway2 LedgerEntryFlag) []*RippleState {
+		return []*RippleState{
+			newLine(t, pathSource, pathGateway, "100", 0, source),
+			newLine(t, pathSource, pathGateway2, "100", 0, gateway2),
+			newLine(t, pathDestination, pathGateway, "0", 0, 0),
+			newLine(t, pathDestination, pathGateway2, "0", 0, gateway2),
+			newLine(t, pathMaker, pathGateway, "50", 0, 0),
+			newLine(t, pathAMM, pathGateway, "100", 0, 0),
+		}
+	}
+
+	pf := newPathFinder(lines(0, 0)...)
+	checkPaths(t, "Rippling", pf, pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.02/USD/SOURCE Path: GATEWAY2",
+		"Cost: 10.1/USD/SOURCE Path: GATEWAY",
+	)
+	checkPaths(t, "Issuer", pf, pathSource, "USD", "10/USD/GATEWAY",
+		"Cost: 10.1/USD/SOURCE Path: default",
+	)
+	checkPaths(t, "Exchange", pf, pathSource, "XRP", "20/USD/GATEWAY",
+		"Cost: 214.733077/XRP Path: USD/GATEWAY",
+	)
+	checkPaths(t, "Illiquid", pf, pathSource, "XRP", "200/USD/GATEWAY")
+
+	a, err := NewAmount(pathNames.Replace("20/USD/GATEWAY"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	found, err := pf.Find(pathAccount(t, pathSource), zeroCurrency, pathAccount(t, pathDestination), *a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if paths := fmt.Sprint(found.PathSet()); paths != pathNames.Replace("[USD/GATEWAY]") {
+		t.Errorf("Expected a PathSet of USD/GATEWAY got %s", paths)
+	}
+
+	withQuality := lines(0, 0)
+	quality := uint32(500000000)
+	if isLow(withQuality[3], pathAccount(t, pathDestination)) {
+		withQuality[3].LowQualityIn = &quality
+	} else {
+		withQuality[3].HighQualityIn = &quality
+	}
+	checkPaths(t, "QualityIn", newPathFinder(withQuality...), pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.1/USD/SOURCE Path: GATEWAY",
+		"Cost: 20.04/USD/SOURCE Path: GATEWAY2",
+	)
+	checkPaths(t, "NoRipple", newPathFinder(lines(0, LsLowNoRipple)...), pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.1/USD/SOURCE Path: GATEWAY",
+	)
+	checkPaths(t, "Freeze", newPathFinder(lines(LsLowFreeze, 0)...), pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.02/USD/SOURCE Path: GATEWAY2",
+	)
+
+	frozen := newPathFinder(lines(0, 0)...)
+	flags := LsGlobalFreeze
+	frozen.accounts[pathAccount(t, pathGateway)].Flags = &flags
+	checkPaths(t, "GlobalFreeze", frozen, pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.02/USD/SOURCE Path: GATEWAY2",
+	)
+}
+
+func TestFindWide(t *testing.T) {
+	var entries LedgerEntrySlice
+	if err := json.Unmarshal([]byte(pathNames.Replace(pathEntries)), &entries); err != nil {
+		t.Fatal(err)
+	}
+	gateway3 := Account{0xFF}.String()
+	entries = append(entries,
+		newLine(t, pathSource, pathGateway, "100", 0, 0),
+		newLine(t, pathDestination, pathGateway2, "0", 0, 0),
+	)
+	// Every holder can ripple between every gateway, so an exhaustive
+	// search would try them in every order
+	for i := 0; i < 200; i++ {
+		holder := Account{0x80, byte(i)}.String()
+		for _, gateway := range []string{pathGateway, pathGateway2, gateway3} {
+			entries = append(entries, newLine(t, holder, gateway, "100", 0, 0))
+		}
+	}
+	pf, err := NewPathFinder(entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first, second := Account{0x80, 0}.String(), Account{0x80, 1}.String()
+	checkPaths(t, "Wide", pf, pathSource, "USD", "10/USD/DESTINATION",
+		"Cost: 10.1202/USD/SOURCE Path: GATEWAY => "+first+" => GATEWAY2",
+		"Cost: 10.1202/USD/SOURCE Path: GATEWAY => "+first+" => "+gateway3+" => "+second+" => GATEWAY2",
+	)
+}
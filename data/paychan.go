@@ -0,0 +1,217 @@
+package data
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sync"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+// ChannelClaim authorises the destination of a payment channel to claim up
+// to Amount in total from the channel. It is signed off-ledger by the key in
+// the channel's PublicKey and is only submitted by the destination.
+type ChannelClaim struct {
+	Channel   Hash256
+	Amount    Amount
+	Signature VariableLength
+}
+
+// claimDrops returns the drops of a claim amount, which must be XRP
+func claimDrops(amount Amount) (uint64, error) {
+	if amount.Value == nil || !amount.IsNative() || amount.IsNegative() {
+		return 0, fmt.Errorf("Channel claim amount must be XRP")
+	}
+	return amount.num, nil
+}
+
+// claimData is the 'CLM' prefix, the channel and the amount in drops
+func claimData(channel Hash256, amount Amount) ([]byte, []byte, error) {
+	drops, err := claimDrops(amount)
+	if err != nil {
+		return nil, nil, err
+	}
+	msg := append(HP_PAYMENT_CHANNEL_CLAIM.Bytes(), channel.Bytes()...)
+	msg = binary.BigEndian.AppendUint64(msg, drops)
+	return crypto.Sha512Half(msg), msg, nil
+}
+
+// AuthorizeChannelClaim signs a claim for amount from channel, in the same
+// way as the channel_authorize command
+func AuthorizeChannelClaim(signer crypto.Signer, channel Hash256, amount Amount) (*ChannelClaim, error) {
+	hash, msg, err := claimData(channel, amount)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := signer.Sign(hash, msg)
+	if err != nil {
+		return nil, err
+	}
+	return &ChannelClaim{
+		Channel:   channel,
+		Amount:    *amount.Clone(),
+		Signature: sig,
+	}, nil
+}
+
+// VerifyChannelClaim checks the signature of a claim, in the same way as the
+// channel_verify command
+func VerifyChannelClaim(publicKey PublicKey, c *ChannelClaim) (bool, error) {
+	hash, msg, err := claimData(c.Channel, c.Amount)
+	if err != nil {
+		return false, err
+	}
+	return crypto.Verify(publicKey.Bytes(), hash, msg, c.Signature)
+}
+
+// Check verifies a claim against the ledger state of its channel. The claim
+// must be signed by the channel's key, must not exceed the channel's Amount
+// and must be for more than has already been claimed.
+func (c *ChannelClaim) Check(channel *PayChannel) error {
+	if channel.PublicKey == nil || channel.Amount == nil {
+		return fmt.Errorf("Incomplete PayChannel")
+	}
+	ok, err := VerifyChannelClaim(*channel.PublicKey, c)
+	switch {
+	case err != nil:
+		return err
+	case !ok:
+		return fmt.Errorf("Bad channel claim signature")
+	}
+	drops, _ := claimDrops(c.Amount)
+	amount, balance, err := channelDrops(channel)
+	switch {
+	case err != nil:
+		return err
+	case drops > amount:
+		return fmt.Errorf("Claim of %d drops exceeds channel amount: %d", drops, amount)
+	case drops <= balance:
+		return fmt.Errorf("Claim of %d drops does not exceed channel balance: %d", drops, balance)
+	default:
+		return nil
+	}
+}
+
+// Transaction returns the PaymentChannelClaim with which the destination
+// claims c. publicKey must be the channel's PublicKey.
+func (c *ChannelClaim) Transaction(account Account, publicKey PublicKey) *PaymentChannelClaim {
+	signature := append(VariableLength(nil), c.Signature...)
+	tx := TxFactory[PAYCHAN_CLAIM]().(*PaymentChannelClaim)
+	tx.Account = account
+	tx.Channel = c.Channel
+	tx.Balance = c.Amount.Clone()
+	tx.Amount = c.Amount.Clone()
+	tx.Signature = &signature
+	tx.PublicKey = &publicKey
+	return tx
+}
+
+// channelDrops returns the Amount and Balance of a channel in drops
+func channelDrops(channel *PayChannel) (uint64, uint64, error) {
+	if channel.Amount == nil {
+		return 0, 0, fmt.Errorf("Incomplete PayChannel")
+	}
+	amount, err := claimDrops(*channel.Amount)
+	if err != nil {
+		return 0, 0, err
+	}
+	var balance uint64
+	if channel.Balance != nil {
+		if balance, err = claimDrops(*channel.Balance); err != nil {
+			return 0, 0, err
+		}
+	}
+	return amount, balance, nil
+}
+
+// ChannelClient authorises a stream of payments through a channel on behalf
+// of its source. Each payment is a claim for the cumulative amount paid so
+// far, which may not exceed the channel's Amount. It may be used by multiple
+// goroutines.
+type ChannelClient struct {
+	Channel    Hash256
+	mu         sync.Mutex
+	signer     crypto.Signer
+	amount     uint64
+	authorised uint64
+}
+
+// NewChannelClient returns a ChannelClient for the channel with ledger state
+// pc. The signer's key must be the channel's PublicKey.
+func NewChannelClient(signer crypto.Signer, channel Hash256, pc *PayChannel) (*ChannelClient, error) {
+	public, err := signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	if pc.PublicKey == nil || !bytes.Equal(public, pc.PublicKey.Bytes()) {
+		return nil, fmt.Errorf("Signer key is not the PublicKey of channel: %s", channel)
+	}
+	c := &ChannelClient{Channel: channel, signer: signer}
+	if err := c.Update(pc); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update refreshes the channel's Amount and Balance, after it is funded or
+// a claim is redeemed. Amounts already authorised are kept unless the
+// destination has claimed more.
+func (c *ChannelClient) Update(pc *PayChannel) error {
+	amount, balance, err := channelDrops(pc)
+	if err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.amount = amount
+	if c.authorised < balance {
+		c.authorised = balance
+	}
+	return nil
+}
+
+// Pay authorises a further payment of drops and returns the claim for the
+// new cumulative amount
+func (c *ChannelClient) Pay(drops uint64) (*ChannelClaim, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if drops == 0 {
+		return nil, fmt.Errorf("Payment of zero drops")
+	}
+	if drops > c.available() {
+		return nil, fmt.Errorf("Payment of %d drops exceeds channel funds: %d", drops, c.available())
+	}
+	total := c.authorised + drops
+	value, err := NewNativeValue(int64(total))
+	if err != nil {
+		return nil, err
+	}
+	claim, err := AuthorizeChannelClaim(c.signer, c.Channel, Amount{Value: value})
+	if err != nil {
+		return nil, err
+	}
+	c.authorised = total
+	return claim, nil
+}
+
+// Authorised returns the cumulative amount of drops authorised
+func (c *ChannelClient) Authorised() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.authorised
+}
+
+// Available returns the drops which may still be paid through the channel
+func (c *ChannelClient) Available() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.available()
+}
+
+func (c *ChannelClient) available() uint64 {
+	if c.authorised > c.amount {
+		return 0
+	}
+	return c.amount - c.authorised
+}
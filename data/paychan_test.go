@@ -0,0 +1,143 @@
+package data
+
+import (
+	"encoding/hex"
+	"regexp"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+func TestChannelClaim(t *testing.T) {
+	seed, err := NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	source := seed.AccountId(Ed25519, nil)
+	destination := seed.AccountId(ECDSA, new(uint32))
+	channel, err := GetPayChannelIndex(source, destination, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKey PublicKey
+	copy(publicKey[:], seed.Key(Ed25519).Public(nil))
+	amount, balance := drops(t, 1000), drops(t, 100)
+	pc := &PayChannel{
+		Account:     &source,
+		Destination: &destination,
+		Amount:      amount,
+		Balance:     balance,
+		PublicKey:   &publicKey,
+	}
+	signer := crypto.NewKeySigner(seed.Key(Ed25519), nil)
+
+	claim, err := AuthorizeChannelClaim(signer, *channel, *drops(t, 500))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := VerifyChannelClaim(publicKey, claim); err != nil || !ok {
+		t.Fatalf("Claim did not verify: %v", err)
+	}
+	if err := claim.Check(pc); err != nil {
+		t.Fatal(err)
+	}
+	tx := claim.Transaction(destination, publicKey)
+	if tx.GetTransactionType() != PAYCHAN_CLAIM || !tx.Balance.Equals(claim.Amount) {
+		t.Fatalf("Bad PaymentChannelClaim: %+v", tx)
+	}
+	if _, err := AuthorizeChannelClaim(signer, *channel, *usd(t, "500")); err == nil {
+		t.Fatal("Claim for an issued currency was accepted")
+	}
+	for _, test := range []struct {
+		claim *ChannelClaim
+		err   string
+	}{
+		{&ChannelClaim{Channel: *channel, Amount: *drops(t, 600), Signature: claim.Signature}, "Bad channel claim signature"},
+		{mustClaim(t, signer, *channel, 1001), "Claim of 1001 drops exceeds channel amount: 1000"},
+		{mustClaim(t, signer, *channel, 100), "Claim of 100 drops does not exceed channel balance: 100"},
+	} {
+		if err := test.claim.Check(pc); err == nil || !regexp.MustCompile(test.err).MatchString(err.Error()) {
+			t.Fatalf("Expected error: %s got: %v", test.err, err)
+		}
+	}
+
+	client, err := NewChannelClient(signer, *channel, pc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []uint64{200, 300, 400} {
+		claim, err := client.Pay(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := claim.Check(pc); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if client.Authorised() != 1000 || client.Available() != 0 {
+		t.Fatalf("Expected 1000 authorised got: %d %d", client.Authorised(), client.Available())
+	}
+	if _, err := client.Pay(1); err == nil {
+		t.Fatal("Payment beyond channel funds was accepted")
+	}
+	pc.Amount = drops(t, 2000)
+	if err := client.Update(pc); err != nil {
+		t.Fatal(err)
+	}
+	if client.Available() != 1000 {
+		t.Fatalf("Expected 1000 available got: %d", client.Available())
+	}
+	if _, err := NewChannelClient(crypto.NewKeySigner(seed.Key(ECDSA), new(uint32)), *channel, pc); err == nil {
+		t.Fatal("Signer with the wrong key was accepted")
+	}
+}
+
+// Example from the channel_verify documentation
+func TestChannelVerify(t *testing.T) {
+	channel, err := NewHash256("5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3")
+	if err != nil {
+		t.Fatal(err)
+	}
+	hash, err := crypto.NewRippleHashCheck("aB44YfzW24VDEJQ2UuLPV2PvqcPCSoLnL7y5M1EzhdW4LnK5xMS3", crypto.RIPPLE_ACCOUNT_PUBLIC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var publicKey PublicKey
+	copy(publicKey[:], hash.Payload())
+	sig, err := hex.DecodeString("304402204EF0AFB78AC23ED1C472E74F4299C0C21F1B21D07EFC0A3838A420F76D783A400220154FB11B6F54320666E4C36CA7F686C16A3A0456800BBC43746F34AF50290064")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claim := &ChannelClaim{Channel: *channel, Amount: *drops(t, 1000000), Signature: sig}
+	if ok, err := VerifyChannelClaim(publicKey, claim); err != nil || !ok {
+		t.Fatalf("Claim did not verify: %v", err)
+	}
+	claim.Amount = *drops(t, 1000001)
+	if ok, _ := VerifyChannelClaim(publicKey, claim); ok {
+		t.Fatal("Claim for a different amount verified")
+	}
+}
+
+func drops(t *testing.T, n int64) *Amount {
+	value, err := NewNativeValue(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Amount{Value: value}
+}
+
+func usd(t *testing.T, s string) *Amount {
+	amount, err := NewAmount(s + "/USD/rrrrrrrrrrrrrrrrrrrrBZbvji")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return amount
+}
+
+func mustClaim(t *testing.T, signer crypto.Signer, channel Hash256, n int64) *ChannelClaim {
+	claim, err := AuthorizeChannelClaim(signer, channel, *drops(t, n))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return claim
+}
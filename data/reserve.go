@@ -0,0 +1,78 @@
+package data
+
+import (
+	"fmt"
+)
+
+// Reserve is the XRP that an account must hold and cannot send: a base
+// reserve for the account itself and an owner reserve for each object it
+// owns, such as a trust line, offer, escrow or ticket.
+type Reserve struct {
+	Base      uint64 // drops
+	Increment uint64 // drops for each object owned
+}
+
+// NewReserve returns the reserve of the ledger with the FeeSettings
+func NewReserve(settings *FeeSettings) (*Reserve, error) {
+	_, base, increment, err := settings.Drops()
+	if err != nil {
+		return nil, err
+	}
+	return &Reserve{Base: base, Increment: increment}, nil
+}
+
+// Required returns the reserve in drops of an account owning ownerCount
+// objects
+func (r Reserve) Required(ownerCount uint32) uint64 {
+	return r.Base + uint64(ownerCount)*r.Increment
+}
+
+// AccountReserve is the reserve and spendable XRP of an account, all in drops
+type AccountReserve struct {
+	Balance      uint64
+	OwnerCount   uint32
+	BaseReserve  uint64
+	OwnerReserve uint64
+	// Spendable is the XRP above the reserve, from which payments and
+	// their fees are paid
+	Spendable uint64
+}
+
+// Account returns the reserve and spendable XRP of the account of root after
+// it owns extra more objects, which may be negative for objects removed
+func (r Reserve) Account(root *AccountRoot, extra int) (*AccountReserve, error) {
+	if root.Balance == nil || !root.Balance.IsNative() || root.Balance.IsNegative() {
+		return nil, fmt.Errorf("AccountRoot has no XRP Balance")
+	}
+	var count int64
+	if root.OwnerCount != nil {
+		count = int64(*root.OwnerCount)
+	}
+	count += int64(extra)
+	if count < 0 {
+		return nil, fmt.Errorf("Account owns %d objects, not %d", count-int64(extra), -extra)
+	}
+	a := &AccountReserve{
+		Balance:      root.Balance.num,
+		OwnerCount:   uint32(count),
+		BaseReserve:  r.Base,
+		OwnerReserve: uint64(count) * r.Increment,
+	}
+	if required := a.BaseReserve + a.OwnerReserve; a.Balance > required {
+		a.Spendable = a.Balance - required
+	}
+	return a, nil
+}
+
+// Affordable returns how many more objects the account of root can own
+// before its balance falls below the reserve
+func (r Reserve) Affordable(root *AccountRoot) (uint64, error) {
+	a, err := r.Account(root, 0)
+	if err != nil {
+		return 0, err
+	}
+	if r.Increment == 0 {
+		return 0, fmt.Errorf("Zero owner reserve")
+	}
+	return a.Spendable / r.Increment, nil
+}
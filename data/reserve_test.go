@@ -0,0 +1,45 @@
+package data
+
+import "testing"
+
+func TestReserve(t *testing.T) {
+	reserve, err := NewReserve(&FeeSettings{
+		BaseFeeDrops:          drops(t, 10),
+		ReserveBaseDrops:      drops(t, 1000000),
+		ReserveIncrementDrops: drops(t, 200000),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	balance, err := NewNativeValue(2500000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ownerCount := uint32(3)
+	root := &AccountRoot{Balance: balance, OwnerCount: &ownerCount}
+	for _, test := range []struct {
+		extra        int
+		ownerReserve uint64
+		spendable    uint64
+	}{
+		{0, 600000, 900000},
+		{4, 1400000, 100000},
+		{5, 1600000, 0},
+		{10, 2600000, 0},
+		{-3, 0, 1500000},
+	} {
+		a, err := reserve.Account(root, test.extra)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if a.BaseReserve != 1000000 || a.OwnerReserve != test.ownerReserve || a.Spendable != test.spendable {
+			t.Fatalf("%d more objects: bad reserve: %+v", test.extra, a)
+		}
+	}
+	if _, err := reserve.Account(root, -4); err == nil {
+		t.Fatal("Negative owner count was accepted")
+	}
+	if n, err := reserve.Affordable(root); err != nil || n != 4 {
+		t.Fatalf("Expected 4 affordable objects got: %d %v", n, err)
+	}
+}
@@ -143,6 +143,7 @@ const (
 	temBAD_OFFER
 	temBAD_PATH
 	temBAD_PATH_LOOP
+	temBAD_REGKEY
 	temBAD_SEND_XRP_LIMIT
 	temBAD_SEND_XRP_MAX
 	temBAD_SEND_XRP_NO_DIRECT
@@ -165,6 +166,7 @@ const (
 	temBAD_TICK_SIZE
 	temINVALID_ACCOUNT_ID
 	temCANNOT_PREAUTH_SELF
+	temINVALID_COUNT
 	temUNCERTAIN
 	temUNKNOWN
 	temSEQ_AND_TICKET
@@ -373,6 +375,7 @@ var resultNames = map[TransactionResult]struct {
 	temBAD_OFFER:                   {"temBAD_OFFER", "Malformed: Bad offer."},
 	temBAD_PATH:                    {"temBAD_PATH", "Malformed: Bad path."},
 	temBAD_PATH_LOOP:               {"temBAD_PATH_LOOP", "Malformed: Loop in path."},
+	temBAD_REGKEY:                  {"temBAD_REGKEY", "Malformed: Regular key cannot be same as master key."},
 	temBAD_SIGNATURE:               {"temBAD_SIGNATURE", "Malformed: Bad signature."},
 	temBAD_SRC_ACCOUNT:             {"temBAD_SRC_ACCOUNT", "Malformed: Bad source account."},
 	temBAD_TRANSFER_RATE:           {"temBAD_TRANSFER_RATE", "Malformed: Transfer rate must be >= 1.0"},
@@ -394,8 +397,11 @@ var resultNames = map[TransactionResult]struct {
 	temBAD_TICK_SIZE:               {"temBAD_TICK_SIZE", "Malformed: Tick size out of range."},
 	temINVALID_ACCOUNT_ID:          {"temINVALID_ACCOUNT_ID", "Malformed: A field contains an invalid account ID."},
 	temCANNOT_PREAUTH_SELF:         {"temCANNOT_PREAUTH_SELF", "Malformed: An account may not preauthorize itself."},
+	temINVALID_COUNT:               {"temINVALID_COUNT", "Malformed: Count field outside valid range."},
 	temSEQ_AND_TICKET:              {"temSEQ_AND_TICKET", "Transaction contains a TicketSequence and a non-zero Sequence"},
 	temBAD_NFTOKEN_TRANSFER_FEE:    {"temBAD_NFTOKEN_TRANSFER_FEE", "Malformed: The NFToken transfer fee must be between 1 and 5000, inclusive."},
+	temBAD_SIGNER:                  {"temBAD_SIGNER", "Malformed: No signer may duplicate account or other signers."},
+	temBAD_QUORUM:                  {"temBAD_QUORUM", "Malformed: Quorum is unreachable."},
 	temBAD_WEIGHT:                  {"temBAD_WEIGHT", "The SignerListSet transaction includes a SignerWeight that is invalid, for example a zero or negative value."},
 	temBAD_AMM_TOKENS:              {"temBAD_AMM_TOKENS", ""},
 	temXCHAIN_EQUAL_DOOR_ACCOUNTS:  {"temXCHAIN_EQUAL_DOOR_ACCOUNTS", ""},
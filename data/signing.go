@@ -7,13 +7,23 @@ import (
 )
 
 func Sign(s Signable, key crypto.Key, sequence *uint32) error {
+	return SignWith(s, crypto.NewKeySigner(key, sequence))
+}
+
+// SignWith signs with a Signer, such as one for a key held by another process
+// or a hardware token
+func SignWith(s Signable, signer crypto.Signer) error {
 	s.InitialiseForSigning()
-	copy(s.GetPublicKey().Bytes(), key.Public(sequence))
+	public, err := signer.PublicKey()
+	if err != nil {
+		return err
+	}
+	copy(s.GetPublicKey().Bytes(), public)
 	hash, msg, err := SigningHash(s)
 	if err != nil {
 		return err
 	}
-	sig, err := crypto.Sign(key.Private(sequence), hash.Bytes(), append(s.SigningPrefix().Bytes(), msg...))
+	sig, err := signer.Sign(hash.Bytes(), append(s.SigningPrefix().Bytes(), msg...))
 	if err != nil {
 		return err
 	}
@@ -31,10 +41,16 @@ func CheckSignature(s Signable) (bool, error) {
 	if err != nil {
 		return false, err
 	}
+	msg = append(s.SigningPrefix().Bytes(), msg...)
 	return crypto.Verify(s.GetPublicKey().Bytes(), hash.Bytes(), msg, s.GetSignature().Bytes())
 }
 
 func MultiSign(s MultiSignable, key crypto.Key, sequence *uint32, account Account) error {
+	return MultiSignWith(s, crypto.NewKeySigner(key, sequence), account)
+}
+
+// MultiSignWith signs for account with a Signer
+func MultiSignWith(s MultiSignable, signer crypto.Signer, account Account) error {
 	s.InitialiseForSigning()
 	hash, msg, err := MultiSigningHash(s, account)
 	if err != nil {
@@ -44,13 +60,17 @@ func MultiSign(s MultiSignable, key crypto.Key, sequence *uint32, account Accoun
 	msg = append(s.MultiSigningPrefix().Bytes(), msg...)
 	msg = append(msg, account.Bytes()...)
 
-	sig, err := crypto.Sign(key.Private(sequence), hash.Bytes(), msg)
+	sig, err := signer.Sign(hash.Bytes(), msg)
+	if err != nil {
+		return err
+	}
+	public, err := signer.PublicKey()
 	if err != nil {
 		return err
 	}
 	*s.GetSignature() = sig
 	// copy pub key only after the signing
-	copy(s.GetPublicKey().Bytes(), key.Public(sequence))
+	copy(s.GetPublicKey().Bytes(), public)
 
 	return nil
 }
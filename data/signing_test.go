@@ -0,0 +1,52 @@
+package data
+
+import (
+	"crypto/ed25519"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+)
+
+// An Ed25519 signature is over the signing prefix and the fields, as
+// rippled signs them, rather than over the fields alone
+func TestCheckEd25519Signature(t *testing.T) {
+	key, err := crypto.NewEd25519Key([]byte("masterpassphrase"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount, err := NewAmount("1000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fee, err := NewValue("10", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payment := &Payment{TxBase: TxBase{TransactionType: PAYMENT, Fee: *fee}, Amount: *amount}
+	copy(payment.Account[:], key.Id(nil))
+	payment.InitialiseForSigning()
+	copy(payment.GetPublicKey().Bytes(), key.Public(nil))
+	_, msg, err := SigningHash(payment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// STX\0
+	prefixed := append([]byte{0x53, 0x54, 0x58, 0x00}, msg...)
+	for _, test := range []struct {
+		name     string
+		signed   []byte
+		expected bool
+	}{
+		{"prefixed", prefixed, true},
+		{"unprefixed", msg, false},
+	} {
+		*payment.GetSignature() = ed25519.Sign(key.Private(nil), test.signed)
+		ok, err := CheckSignature(payment)
+		if err != nil {
+			t.Fatalf("%s: %s", test.name, err)
+		}
+		if ok != test.expected {
+			t.Errorf("%s: Expected %t got %t", test.name, test.expected, ok)
+		}
+	}
+}
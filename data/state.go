@@ -0,0 +1,314 @@
+package data
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// State is a set of ledger entries keyed by LedgerIndex which is rolled
+// forward by applying the metadata of transactions and rolled backward by
+// reverting it.
+//
+// Entries which the State does not hold are built from the metadata alone
+// when a transaction modifies them. Metadata never records a Directory's
+// Indexes, nor a field which a transaction added to an existing entry, so
+// such entries and reverted entries may lack those fields.
+type State map[Hash256]LedgerEntry
+
+// createdDefaults names the required fields of each LedgerEntryType which
+// NewFields omits when they hold their default value. Flags is required by
+// every type.
+var createdDefaults = map[LedgerEntryType][]string{
+	ACCOUNT_ROOT:     {"OwnerCount"},
+	OFFER:            {"BookNode", "OwnerNode"},
+	ESCROW:           {"OwnerNode"},
+	SIGNER_LIST:      {"OwnerNode", "SignerListID"},
+	TICKET:           {"OwnerNode"},
+	PAY_CHANNEL:      {"Balance", "OwnerNode"},
+	CHECK:            {"OwnerNode", "DestinationNode"},
+	DEPOSIT_PRE_AUTH: {"OwnerNode"},
+	NFTOKEN_OFFER:    {"Amount", "OwnerNode", "NFTokenOfferNode"},
+	AMM_LT:           {"OwnerNode"},
+}
+
+// unthreaded are the LedgerEntryTypes which are created without a
+// PreviousTxnID
+var unthreaded = map[LedgerEntryType]bool{
+	DIRECTORY:     true,
+	AMENDMENTS:    true,
+	LEDGER_HASHES: true,
+	FEE_SETTINGS:  true,
+	NEGATIVE_UNL:  true,
+	AMM_LT:        true,
+}
+
+// NewState returns a State holding entries, each of which must have a
+// LedgerIndex or have been decoded from binary with its index
+func NewState(entries LedgerEntrySlice) (State, error) {
+	s := make(State, len(entries))
+	for _, le := range entries {
+		if err := s.Add(le); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Add adds or replaces le, setting its LedgerIndex if it was decoded from
+// binary
+func (s State) Add(le LedgerEntry) error {
+	index := entryIndex(le)
+	if index == nil {
+		return fmt.Errorf("%s has no LedgerIndex", le.GetType())
+	}
+	if le.GetLedgerIndex() == nil {
+		le = cloneEntry(le)
+		setIndex(le, *index)
+	}
+	s[*index] = le
+	return nil
+}
+
+// entryIndex returns the LedgerIndex of le, or for an entry decoded from
+// binary the index which followed its fields
+func entryIndex(le LedgerEntry) *Hash256 {
+	if index := le.GetLedgerIndex(); index != nil {
+		return index
+	}
+	if hash := le.GetHash(); !hash.IsZero() {
+		return hash
+	}
+	return nil
+}
+
+// Apply rolls the State forward through txns in ledger and TransactionIndex
+// order. Each transaction must have its LedgerSequence.
+func (s State) Apply(txns TransactionSlice) error {
+	for _, txm := range sorted(txns) {
+		if err := s.apply(txm, txm.LedgerSequence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Revert rolls the State backward through txns in reverse ledger and
+// TransactionIndex order
+func (s State) Revert(txns TransactionSlice) error {
+	txns = sorted(txns)
+	for i := len(txns) - 1; i >= 0; i-- {
+		if err := s.revert(txns[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyLedger rolls the State forward from the previous ledger to l, whose
+// Transactions must all be present
+func (s State) ApplyLedger(l *Ledger) error {
+	for _, txm := range sorted(l.Transactions) {
+		if err := s.apply(txm, l.LedgerSequence); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevertLedger rolls the State backward from l to the previous ledger
+func (s State) RevertLedger(l *Ledger) error {
+	return s.Revert(l.Transactions)
+}
+
+func sorted(txns TransactionSlice) TransactionSlice {
+	s := append(TransactionSlice(nil), txns...)
+	sort.Stable(s)
+	return s
+}
+
+type stateChange struct {
+	index Hash256
+	entry LedgerEntry // nil when deleted
+}
+
+// commit makes all of the changes of a transaction at once, so that a
+// transaction which fails to apply or revert leaves the State unchanged
+func (s State) commit(changes []stateChange) {
+	for _, c := range changes {
+		if c.entry == nil {
+			delete(s, c.index)
+		} else {
+			s[c.index] = c.entry
+		}
+	}
+}
+
+func (s State) apply(txm *TransactionWithMetaData, ledger uint32) error {
+	if ledger == 0 {
+		return fmt.Errorf("Transaction %s has no ledger sequence", txm.GetHash())
+	}
+	hash := *txm.GetHash()
+	changes := make([]stateChange, 0, len(txm.MetaData.AffectedNodes))
+	for i := range txm.MetaData.AffectedNodes {
+		node, final, _, state := txm.MetaData.AffectedNodes[i].AffectedNode()
+		if node.LedgerIndex == nil {
+			return fmt.Errorf("Transaction %s affects %s with no LedgerIndex", hash, node.LedgerEntryType)
+		}
+		index := *node.LedgerIndex
+		current, ok := s[index]
+		switch state {
+		case Created:
+			if ok {
+				return fmt.Errorf("Transaction %s creates %s %s which already exists", hash, node.LedgerEntryType, index)
+			}
+			le := cloneEntry(final)
+			setDefaults(le)
+			if !unthreaded[node.LedgerEntryType] {
+				setThread(le, &hash, &ledger)
+			}
+			setIndex(le, index)
+			changes = append(changes, stateChange{index, le})
+		case Modified:
+			if ok {
+				if err := checkThread(current, node.PreviousTxnID); err != nil {
+					return err
+				}
+			}
+			var le LedgerEntry
+			if node.FinalFields != nil || !ok {
+				le = cloneEntry(final)
+				keepIndexes(le, current)
+			} else {
+				le = cloneEntry(current)
+			}
+			if node.PreviousTxnID != nil {
+				setThread(le, &hash, &ledger)
+			}
+			setIndex(le, index)
+			changes = append(changes, stateChange{index, le})
+		case Deleted:
+			changes = append(changes, stateChange{index, nil})
+		}
+	}
+	s.commit(changes)
+	return nil
+}
+
+func (s State) revert(txm *TransactionWithMetaData) error {
+	hash := *txm.GetHash()
+	changes := make([]stateChange, 0, len(txm.MetaData.AffectedNodes))
+	for i := range txm.MetaData.AffectedNodes {
+		node, final, previous, state := txm.MetaData.AffectedNodes[i].AffectedNode()
+		if node.LedgerIndex == nil {
+			return fmt.Errorf("Transaction %s affects %s with no LedgerIndex", hash, node.LedgerEntryType)
+		}
+		index := *node.LedgerIndex
+		current, ok := s[index]
+		switch state {
+		case Created:
+			changes = append(changes, stateChange{index, nil})
+		case Modified:
+			if ok && node.PreviousTxnID != nil {
+				if err := checkThread(current, &hash); err != nil {
+					return err
+				}
+			}
+			base := current
+			if !ok {
+				base = final
+			}
+			le := cloneEntry(base)
+			mergeFields(le, previous)
+			if node.PreviousTxnID != nil {
+				setThread(le, node.PreviousTxnID, node.PreviousTxnLgrSeq)
+			}
+			setIndex(le, index)
+			changes = append(changes, stateChange{index, le})
+		case Deleted:
+			if ok {
+				return fmt.Errorf("Transaction %s deletes %s %s which still exists", hash, node.LedgerEntryType, index)
+			}
+			le := cloneEntry(final)
+			mergeFields(le, previous)
+			setIndex(le, index)
+			changes = append(changes, stateChange{index, le})
+		}
+	}
+	s.commit(changes)
+	return nil
+}
+
+// checkThread returns an error if le was not last modified by the
+// transaction with hash, which shows that transactions are being applied
+// or reverted out of order
+func checkThread(le LedgerEntry, hash *Hash256) error {
+	previous := le.GetPreviousTxnId()
+	if previous == nil || hash == nil || *previous == *hash {
+		return nil
+	}
+	return fmt.Errorf("%s %s was last modified by %s not %s", le.GetType(), le.GetLedgerIndex(), previous, hash)
+}
+
+// cloneEntry returns a shallow copy of le without its Hash and Id, which
+// will not match the changed fields. Entries are never changed in place, so
+// the copy can share the values of the fields of le.
+func cloneEntry(le LedgerEntry) LedgerEntry {
+	v := reflect.ValueOf(le).Elem()
+	c := reflect.New(v.Type())
+	c.Elem().Set(v)
+	clone := c.Interface().(LedgerEntry)
+	base := entryBase(clone)
+	base.Hash, base.Id = zero256, zero256
+	return clone
+}
+
+func entryBase(le LedgerEntry) *leBase {
+	return le.(interface{ base() *leBase }).base()
+}
+
+func setIndex(le LedgerEntry, index Hash256) {
+	entryBase(le).LedgerIndex = &index
+}
+
+func setThread(le LedgerEntry, hash *Hash256, ledger *uint32) {
+	base := entryBase(le)
+	base.PreviousTxnID, base.PreviousTxnLgrSeq = hash, ledger
+}
+
+// mergeFields sets each field of le which is present in fields, other than
+// those of leBase
+func mergeFields(le, fields LedgerEntry) {
+	dst, src := reflect.ValueOf(le).Elem(), reflect.ValueOf(fields).Elem()
+	for i := 0; i < src.NumField(); i++ {
+		if src.Type().Field(i).Anonymous || src.Field(i).IsZero() {
+			continue
+		}
+		dst.Field(i).Set(src.Field(i))
+	}
+}
+
+// keepIndexes copies the Indexes of a directory, which metadata omits
+func keepIndexes(le, current LedgerEntry) {
+	dir, ok := le.(*Directory)
+	if previous, found := current.(*Directory); ok && found {
+		dir.Indexes = previous.Indexes
+	}
+}
+
+// setDefaults adds the required fields of a created entry which its
+// NewFields omit
+func setDefaults(le LedgerEntry) {
+	v := reflect.ValueOf(le).Elem()
+	for _, name := range append([]string{"Flags"}, createdDefaults[le.GetLedgerEntryType()]...) {
+		field := v.FieldByName(name)
+		if !field.IsNil() {
+			continue
+		}
+		if field.Type() == reflect.TypeOf(&Amount{}) {
+			field.Set(reflect.ValueOf(newAmount(zeroNative.Clone(), Currency{}, Account{})))
+		} else {
+			field.Set(reflect.New(field.Type().Elem()))
+		}
+	}
+}
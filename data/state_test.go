@@ -0,0 +1,87 @@
+package data
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+func readLedger(t *testing.T, path string) *Ledger {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ledger Ledger
+	if err := json.Unmarshal(b, &ledger); err != nil {
+		t.Fatal(err)
+	}
+	return &ledger
+}
+
+func encodeState(t *testing.T, s State) map[Hash256][]byte {
+	nodes := make(map[Hash256][]byte, len(s))
+	for index, le := range s {
+		_, node, err := Node(le)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nodes[index] = node
+	}
+	return nodes
+}
+
+func hash256(t *testing.T, s string) Hash256 {
+	h, err := NewHash256(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *h
+}
+
+func TestState(t *testing.T) {
+	ledger := readLedger(t, "testdata/ledger_6000000.json")
+	state, err := NewState(ledger.AccountState)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := encodeState(t, state)
+
+	if err := state.RevertLedger(ledger); err != nil {
+		t.Fatal(err)
+	}
+	if len(state) != len(want)-1 {
+		t.Fatalf("Expected %d entries got %d", len(want)-1, len(state))
+	}
+	created := hash256(t, "4C6ACBD635B0F07101F7FA25871B0925F8836155462152172755845CE691C49E")
+	if _, ok := state[created]; ok {
+		t.Fatal("Created AccountRoot was not removed")
+	}
+	sender := state[hash256(t, "B33FDD5CF3445E1A7F2BE9B06336BEBD73A5E3EE885D3EF93F7E3E2992E46F1A")].(*AccountRoot)
+	if sender.Balance.String() != "991481.99939" || *sender.Sequence != 62 || *sender.PreviousTxnLgrSeq != 31317 ||
+		sender.PreviousTxnID.String() != "2485FDC606352F1B0785DA5DE96FB9DBAF43EB60ECBB01B7F6FA970F512CDA5F" {
+		t.Fatalf("Bad reverted AccountRoot: %+v", sender)
+	}
+	if err := state.RevertLedger(ledger); err == nil {
+		t.Fatal("Reverted the same ledger twice")
+	}
+
+	if err := state.ApplyLedger(ledger); err != nil {
+		t.Fatal(err)
+	}
+	got := encodeState(t, state)
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d entries got %d", len(want), len(got))
+	}
+	for index, node := range want {
+		if !bytes.Equal(got[index], node) {
+			t.Fatalf("%s: expected %X got %X", index, node, got[index])
+		}
+	}
+	if err := state.ApplyLedger(ledger); err == nil {
+		t.Fatal("Applied the same ledger twice")
+	}
+	if err := state.Apply(ledger.Transactions); err == nil {
+		t.Fatal("Applied transactions without a ledger sequence")
+	}
+}
@@ -0,0 +1,112 @@
+package data
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// MaxNodeSize is the largest node a NodeReader will accept
+const MaxNodeSize = 16 << 20
+
+// NodeReader reads a stream of nodes in the format written by NodeWriter.
+// Each node is framed as its 32 byte node id, a 4 byte big endian length
+// and the prefix format value produced by Node. Only one node is held in
+// memory at a time, so ledger dumps of any size can be replayed.
+type NodeReader struct {
+	r     *bufio.Reader
+	buf   []byte
+	count int
+}
+
+func NewNodeReader(r io.Reader) *NodeReader {
+	return &NodeReader{r: bufio.NewReader(r)}
+}
+
+// Read returns the next node in the stream, or io.EOF at the end of it
+func (n *NodeReader) Read() (Storer, error) {
+	var nodeId Hash256
+	if _, err := io.ReadFull(n.r, nodeId[:]); err != nil {
+		if err == io.EOF {
+			return nil, err
+		}
+		return nil, fmt.Errorf("Node %d: short node id: %w", n.count, err)
+	}
+	var length uint32
+	if err := binary.Read(n.r, binary.BigEndian, &length); err != nil {
+		return nil, fmt.Errorf("Node %d %s: short length: %w", n.count, nodeId, err)
+	}
+	if length > MaxNodeSize {
+		return nil, fmt.Errorf("Node %d %s: length %d exceeds maximum: %d", n.count, nodeId, length, MaxNodeSize)
+	}
+	if cap(n.buf) < int(length) {
+		n.buf = make([]byte, length)
+	}
+	n.buf = n.buf[:length]
+	if _, err := io.ReadFull(n.r, n.buf); err != nil {
+		return nil, fmt.Errorf("Node %d %s: short value: %w", n.count, nodeId, err)
+	}
+	node, err := ReadPrefix(bytes.NewReader(n.buf), nodeId)
+	if err != nil {
+		return nil, fmt.Errorf("Node %d %s: %w", n.count, nodeId, err)
+	}
+	n.count++
+	return node, nil
+}
+
+// Each calls f with every remaining node in the stream
+func (n *NodeReader) Each(f func(Storer) error) error {
+	for {
+		node, err := n.Read()
+		switch {
+		case err == io.EOF:
+			return nil
+		case err != nil:
+			return err
+		}
+		if err := f(node); err != nil {
+			return err
+		}
+	}
+}
+
+// Count returns the number of nodes read so far
+func (n *NodeReader) Count() int {
+	return n.count
+}
+
+// NodeWriter writes nodes in the format read by NodeReader
+type NodeWriter struct {
+	w     io.Writer
+	count int
+}
+
+func NewNodeWriter(w io.Writer) *NodeWriter {
+	return &NodeWriter{w: w}
+}
+
+func (n *NodeWriter) Write(node Storer) error {
+	nodeId, value, err := Node(node)
+	if err != nil {
+		return fmt.Errorf("Node %d: %w", n.count, err)
+	}
+	if len(value) > MaxNodeSize {
+		return fmt.Errorf("Node %d %s: length %d exceeds maximum: %d", n.count, nodeId, len(value), MaxNodeSize)
+	}
+	var length [4]byte
+	binary.BigEndian.PutUint32(length[:], uint32(len(value)))
+	for _, b := range [][]byte{nodeId[:], length[:], value} {
+		if _, err := n.w.Write(b); err != nil {
+			return err
+		}
+	}
+	n.count++
+	return nil
+}
+
+// Count returns the number of nodes written so far
+func (n *NodeWriter) Count() int {
+	return n.count
+}
@@ -5,6 +5,21 @@ import (
 	"sort"
 )
 
+// LiquiditySource is where the Giver of a Trade provided its liquidity
+type LiquiditySource uint8
+
+const (
+	LiquidityOffer LiquiditySource = iota // An Offer in an order book
+	LiquidityAMM                          // An AMM pool
+)
+
+func (l LiquiditySource) String() string {
+	if l == LiquidityAMM {
+		return "AMM"
+	}
+	return "Offer"
+}
+
 type Trade struct {
 	LedgerSequence   uint32
 	TransactionIndex uint32
@@ -14,6 +29,10 @@ type Trade struct {
 	Got              *Amount
 	Giver            Account
 	Taker            Account
+	Source           LiquiditySource
+	// Bridged is set for each leg of an exchange between two assets other
+	// than XRP which went through XRP, such as by auto-bridging
+	Bridged bool
 }
 
 func newTrade(txm *TransactionWithMetaData, i int) (*Trade, error) {
@@ -55,13 +74,26 @@ func (t *Trade) Rate() float64 {
 	return t.Got.Ratio(*t.Paid).Float()
 }
 
+// Liquidity returns the source of the Trade, followed by "/XRP" when it
+// was bridged
+func (t Trade) Liquidity() string {
+	if t.Bridged {
+		return t.Source.String() + "/XRP"
+	}
+	return t.Source.String()
+}
+
 func (t Trade) String() string {
-	return fmt.Sprintf("%8d %3d %22.8f %22.8f %-38s %22.8f %-38s %34s %34s %11s %s", t.LedgerSequence, t.TransactionIndex, t.Rate(), t.Paid.Float(), t.Paid.Asset(), t.Got.Float(), t.Got.Asset(), t.Taker, t.Giver, t.TransactionType, t.Op)
+	return fmt.Sprintf("%8d %3d %22.8f %22.8f %-38s %22.8f %-38s %34s %34s %11s %-6s %s", t.LedgerSequence, t.TransactionIndex, t.Rate(), t.Paid.Float(), t.Paid.Asset(), t.Got.Float(), t.Got.Asset(), t.Taker, t.Giver, t.TransactionType, t.Op, t.Liquidity())
 
 }
 
 type TradeSlice []Trade
 
+// NewTradeSlice returns a Trade for each Offer which the transaction
+// consumed and for each AMM pool through which it swapped. A payment along
+// several paths or through several books has a Trade for every one of them,
+// each with its own pair of assets.
 func NewTradeSlice(txm *TransactionWithMetaData) (TradeSlice, error) {
 	var trades TradeSlice
 	for i := range txm.MetaData.AffectedNodes {
@@ -73,10 +105,158 @@ func NewTradeSlice(txm *TransactionWithMetaData) (TradeSlice, error) {
 			trades = append(trades, *trade)
 		}
 	}
+	swaps, err := newAMMTrades(txm)
+	if err != nil {
+		return nil, err
+	}
+	trades = append(trades, swaps...)
+	if bridged(txm) {
+		for i := range trades {
+			trades[i].Bridged = trades[i].Paid.IsNative() || trades[i].Got.IsNative()
+		}
+	}
 	trades.Sort()
 	return trades, nil
 }
 
+// bridged returns whether txm exchanges two assets neither of which is XRP
+func bridged(txm *TransactionWithMetaData) bool {
+	var in, out *Amount
+	switch tx := txm.Transaction.(type) {
+	case *OfferCreate:
+		in, out = &tx.TakerGets, &tx.TakerPays
+	case *Payment:
+		in, out = tx.SendMax, &tx.Amount
+		if in == nil {
+			in = out
+		}
+	default:
+		return false
+	}
+	return !in.IsNative() && !out.IsNative() && *in.Asset() != *out.Asset()
+}
+
+// ammHolder returns the AMM which holds the balance of a trust line with
+// LsAMMNode set
+func ammHolder(f, p *RippleState) *Account {
+	if f.Flags == nil || *f.Flags&LsAMMNode == 0 || f.LowLimit == nil || f.HighLimit == nil {
+		return nil
+	}
+	balance := f.Balance
+	if (balance == nil || balance.IsZero()) && p.Balance != nil {
+		balance = p.Balance
+	}
+	switch {
+	case balance == nil || balance.IsZero():
+		return nil
+	case balance.IsNegative():
+		return &f.HighLimit.Issuer
+	default:
+		return &f.LowLimit.Issuer
+	}
+}
+
+// ammPool is the change of the assets of an AMM pool
+type ammPool struct {
+	account Account
+	changes []*Amount
+}
+
+// newAMMTrades returns a Trade for each AMM pool which received one asset
+// and gave out another. The pools are found from the AccountRoots with an
+// AMMID and from their trust lines, which have LsAMMNode set. A pool which
+// holds two tokens need not have its AccountRoot modified, but is always
+// the holder rather than the issuer on its trust lines.
+func newAMMTrades(txm *TransactionWithMetaData) (TradeSlice, error) {
+	amms := make(map[Account]bool)
+	for i := range txm.MetaData.AffectedNodes {
+		_, final, previous, _ := txm.MetaData.AffectedNodes[i].AffectedNode()
+		switch f := final.(type) {
+		case *AccountRoot:
+			if f.AMMID != nil && f.Account != nil {
+				amms[*f.Account] = true
+			}
+		case *AMM:
+			if f.Account != nil {
+				amms[*f.Account] = true
+			}
+		case *RippleState:
+			if amm := ammHolder(f, previous.(*RippleState)); amm != nil {
+				amms[*amm] = true
+			}
+		}
+	}
+	if len(amms) == 0 {
+		return nil, nil
+	}
+	var pools []*ammPool
+	pool := func(account Account) *ammPool {
+		for _, p := range pools {
+			if p.account == account {
+				return p
+			}
+		}
+		p := &ammPool{account: account}
+		pools = append(pools, p)
+		return p
+	}
+	for i := range txm.MetaData.AffectedNodes {
+		_, final, previous, state := txm.MetaData.AffectedNodes[i].AffectedNode()
+		if state == Created {
+			continue
+		}
+		switch f := final.(type) {
+		case *AccountRoot:
+			p := previous.(*AccountRoot)
+			if f.Account == nil || !amms[*f.Account] || p.Balance == nil || f.Balance == nil {
+				continue
+			}
+			change, err := f.Balance.Subtract(*p.Balance)
+			if err != nil {
+				return nil, err
+			}
+			pool(*f.Account).changes = append(pool(*f.Account).changes, newAmount(change, zeroCurrency, zeroAccount))
+		case *RippleState:
+			p := previous.(*RippleState)
+			amm := ammHolder(f, p)
+			if amm == nil || p.Balance == nil || f.Balance == nil {
+				continue
+			}
+			change, err := f.Balance.Value.Subtract(*p.Balance.Value)
+			if err != nil {
+				return nil, err
+			}
+			issuer := f.HighLimit.Issuer
+			if *amm == issuer {
+				issuer, change = f.LowLimit.Issuer, change.Negate()
+			}
+			pool(*amm).changes = append(pool(*amm).changes, newAmount(change, f.Balance.Currency, issuer))
+		}
+	}
+	var trades TradeSlice
+	for _, p := range pools {
+		if len(p.changes) != 2 || p.changes[0].IsNegative() == p.changes[1].IsNegative() {
+			continue
+		}
+		paid, got := p.changes[0], p.changes[1]
+		if paid.IsNegative() {
+			paid, got = got, paid
+		}
+		trades = append(trades, Trade{
+			LedgerSequence:   txm.LedgerSequence,
+			TransactionIndex: txm.MetaData.TransactionIndex,
+			TransactionType:  txm.GetTransactionType().String(),
+			Op:               "Swap",
+			Paid:             paid,
+			Got:              got.Abs(),
+			Giver:            p.account,
+			Taker:            txm.Transaction.GetBase().Account,
+			Source:           LiquidityAMM,
+		})
+	}
+	return trades, nil
+}
+
 func (s TradeSlice) Filter(account Account) TradeSlice {
 	var trades TradeSlice
 	for i := range s {
@@ -0,0 +1,100 @@
+package data
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+const (
+	tradeTaker   = "r3kmLJN5D28dHuH8vZNUZpMC43pEHpaocV"
+	tradeMaker   = "rLQBHVhFnaC5gLEkgr6HgBJJ3bgeZHg9cj"
+	tradeAMM     = "rpDMez6pm6dBve2TJsmDpv7Yae6V5Pyvy2"
+	tradeIssuer  = "rnziParaNb8nsU4aruQdwYE3j5jUcqjzFm"
+	tradeBridger = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+)
+
+func checkTrades(t *testing.T, name, s string, want ...string) {
+	t.Helper()
+	s = strings.NewReplacer("TAKER", tradeTaker, "MAKER", tradeMaker, "AMM", tradeAMM, "ISSUER", tradeIssuer, "BRIDGER", tradeBridger).Replace(s)
+	trades, err := NewTradeSlice(parseTransaction(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, trade := range trades {
+		got = append(got, fmt.Sprintf("%s %s %s %s", trade.Giver, trade.Paid, trade.Got, trade.Liquidity()))
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("%s: Expected:\n%s\nGot:\n%s", name, strings.Join(want, "\n"), strings.Join(got, "\n"))
+	}
+}
+
+// Sells USD for EUR through the USD/XRP and XRP/EUR books
+const autoBridged = `{
+	"TransactionType": "OfferCreate", "Account": "TAKER", "Fee": "10", "Sequence": 1,
+	"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "5"},
+	"TakerPays": {"currency": "EUR", "issuer": "ISSUER", "value": "5"},
+	"hash": "4444444444444444444444444444444444444444444444444444444444444444",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "Offer", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000001",
+			"FinalFields": {"Account": "MAKER", "Sequence": 1, "Flags": 0,
+				"TakerPays": {"currency": "USD", "issuer": "ISSUER", "value": "5"}, "TakerGets": "50000000"},
+			"PreviousFields": {"TakerPays": {"currency": "USD", "issuer": "ISSUER", "value": "10"}, "TakerGets": "100000000"}}},
+		{"DeletedNode": {"LedgerEntryType": "Offer", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000002",
+			"FinalFields": {"Account": "BRIDGER", "Sequence": 1, "Flags": 0,
+				"TakerPays": "0", "TakerGets": {"currency": "EUR", "issuer": "ISSUER", "value": "0"}},
+			"PreviousFields": {"TakerPays": "50000000", "TakerGets": {"currency": "EUR", "issuer": "ISSUER", "value": "5"}}}}
+	]}
+}`
+
+// Buys USD with XRP from an XRP/USD pool
+const ammSwap = `{
+	"TransactionType": "Payment", "Account": "TAKER", "Destination": "TAKER", "Fee": "10", "Sequence": 2,
+	"Amount": {"currency": "USD", "issuer": "ISSUER", "value": "10"}, "SendMax": "120000000",
+	"hash": "5555555555555555555555555555555555555555555555555555555555555555",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "AccountRoot", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000003",
+			"FinalFields": {"Account": "AMM", "Balance": "1100000000", "Flags": 0, "OwnerCount": 1, "Sequence": 0,
+				"AMMID": "0000000000000000000000000000000000000000000000000000000000000004"},
+			"PreviousFields": {"Balance": "1000000000"}}},
+		{"ModifiedNode": {"LedgerEntryType": "RippleState", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000005",
+			"FinalFields": {"Flags": 16777216, "Balance": {"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-990"},
+				"LowLimit": {"currency": "USD", "issuer": "ISSUER", "value": "0"},
+				"HighLimit": {"currency": "USD", "issuer": "AMM", "value": "0"}},
+			"PreviousFields": {"Balance": {"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-1000"}}}}
+	]}
+}`
+
+// Sells USD for EUR to a USD/EUR pool whose AccountRoot is unchanged
+const tokenSwap = `{
+	"TransactionType": "OfferCreate", "Account": "TAKER", "Fee": "10", "Sequence": 3,
+	"TakerGets": {"currency": "USD", "issuer": "ISSUER", "value": "5"},
+	"TakerPays": {"currency": "EUR", "issuer": "ISSUER", "value": "4"},
+	"hash": "6666666666666666666666666666666666666666666666666666666666666666",
+	"metaData": {"TransactionIndex": 0, "TransactionResult": "tesSUCCESS", "AffectedNodes": [
+		{"ModifiedNode": {"LedgerEntryType": "RippleState", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000005",
+			"FinalFields": {"Flags": 16777216, "Balance": {"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-1005"},
+				"LowLimit": {"currency": "USD", "issuer": "ISSUER", "value": "0"},
+				"HighLimit": {"currency": "USD", "issuer": "AMM", "value": "0"}},
+			"PreviousFields": {"Balance": {"currency": "USD", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-1000"}}}},
+		{"ModifiedNode": {"LedgerEntryType": "RippleState", "LedgerIndex": "0000000000000000000000000000000000000000000000000000000000000006",
+			"FinalFields": {"Flags": 16777216, "Balance": {"currency": "EUR", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-995.5"},
+				"LowLimit": {"currency": "EUR", "issuer": "ISSUER", "value": "0"},
+				"HighLimit": {"currency": "EUR", "issuer": "AMM", "value": "0"}},
+			"PreviousFields": {"Balance": {"currency": "EUR", "issuer": "rrrrrrrrrrrrrrrrrrrrBZbvji", "value": "-1000"}}}}
+	]}
+}`
+
+func TestNewTradeSlice(t *testing.T) {
+	checkTrades(t, "AutoBridged", autoBridged,
+		tradeMaker+" 5/USD/"+tradeIssuer+" 50/XRP Offer/XRP",
+		tradeBridger+" 50/XRP 5/EUR/"+tradeIssuer+" Offer/XRP",
+	)
+	checkTrades(t, "AMMSwap", ammSwap,
+		tradeAMM+" 100/XRP 10/USD/"+tradeIssuer+" AMM",
+	)
+	checkTrades(t, "TokenSwap", tokenSwap,
+		tradeAMM+" 5/USD/"+tradeIssuer+" 4.5/EUR/"+tradeIssuer+" AMM",
+	)
+}
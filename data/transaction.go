@@ -1,5 +1,7 @@
 package data
 
+import "fmt"
+
 type TxBase struct {
 	TransactionType    TransactionType
 	Flags              *TransactionFlag `json:",omitempty"`
@@ -15,6 +17,7 @@ type TxBase struct {
 	PreviousTxnID      *Hash256        `json:",omitempty"`
 	LastLedgerSequence *uint32         `json:",omitempty"`
 	Hash               Hash256         `json:"hash"`
+	Unknown            UnknownFields   `json:"-"`
 }
 
 type SignerItem struct {
@@ -159,21 +162,24 @@ type EscrowCreate struct {
 	TxBase
 	Destination    Account
 	Amount         Amount
-	Digest         *Hash256 `json:",omitempty"`
-	CancelAfter    *uint32  `json:",omitempty"`
-	FinishAfter    *uint32  `json:",omitempty"`
-	DestinationTag *uint32  `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Digest         *Hash256        `json:",omitempty"`
+	Condition      *VariableLength `json:",omitempty"`
+	CancelAfter    *uint32         `json:",omitempty"`
+	FinishAfter    *uint32         `json:",omitempty"`
+	DestinationTag *uint32         `json:",omitempty"`
+	TicketSequence *uint32         `json:",omitempty"`
 }
 
 type EscrowFinish struct {
 	TxBase
 	Owner          Account
 	OfferSequence  uint32
-	Method         *uint8   `json:",omitempty"`
-	Digest         *Hash256 `json:",omitempty"`
-	Proof          *Hash256 `json:",omitempty"`
-	TicketSequence *uint32  `json:",omitempty"`
+	Method         *uint8          `json:",omitempty"`
+	Digest         *Hash256        `json:",omitempty"`
+	Proof          *Hash256        `json:",omitempty"`
+	Condition      *VariableLength `json:",omitempty"`
+	Fulfillment    *VariableLength `json:",omitempty"`
+	TicketSequence *uint32         `json:",omitempty"`
 }
 
 type EscrowCancel struct {
@@ -330,6 +336,24 @@ type OracleDelete struct {
 	OracleDocumentID int `json:"OracleDocumentID"`
 }
 
+// SetDestination sets the Destination from a classic address or an
+// X-address. The tag of an X-address becomes the DestinationTag and must
+// agree with any DestinationTag already set.
+func (p *Payment) SetDestination(address string) error {
+	account, tag, err := ParseAddress(address)
+	if err != nil {
+		return err
+	}
+	if tag != nil {
+		if p.DestinationTag != nil && *p.DestinationTag != *tag {
+			return fmt.Errorf("X-address %s has destination tag %d which conflicts with DestinationTag %d", address, *tag, *p.DestinationTag)
+		}
+		p.DestinationTag = tag
+	}
+	p.Destination = *account
+	return nil
+}
+
 func (t *TxBase) GetBase() *TxBase                    { return t }
 func (t *TxBase) GetType() string                     { return txNames[t.TransactionType] }
 func (t *TxBase) GetTransactionType() TransactionType { return t.TransactionType }
@@ -339,6 +363,7 @@ func (t *TxBase) GetSignature() *VariableLength       { return t.TxnSignature }
 func (t *TxBase) SigningPrefix() HashPrefix           { return HP_TRANSACTION_SIGN }
 func (t *TxBase) MultiSigningPrefix() HashPrefix      { return HP_TRANSACTION_MULTISIGN }
 func (t *TxBase) SetSigners(signers []Signer)         { t.Signers = signers }
+func (t *TxBase) GetSigners() []Signer                { return t.Signers }
 func (t *TxBase) PathSet() PathSet                    { return PathSet(nil) }
 func (t *TxBase) GetHash() *Hash256                   { return &t.Hash }
 
@@ -0,0 +1,207 @@
+package data
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// UnknownFields holds the encoded values of fields which were present in the
+// binary format but are not modelled by the containing type, keyed by their
+// encoding. They are re-emitted unchanged by the encoder so that a decode and
+// encode round trip reproduces the original bytes and hashes.
+type UnknownFields map[enc][]byte
+
+var unknownFieldsType = reflect.TypeOf(UnknownFields(nil))
+
+// Names returns the names of the unknown fields, or their type and field
+// codes if the field is not known to this library at all.
+func (u UnknownFields) Names() []string {
+	var names []string
+	for e := range u {
+		if name, ok := encodings[e]; ok {
+			names = append(names, name)
+		} else {
+			names = append(names, fmt.Sprintf("%d:%d", e.typ, e.field))
+		}
+	}
+	return names
+}
+
+// rawValue is an already encoded value which is written as is
+type rawValue []byte
+
+// getUnknownFields returns the UnknownFields of the struct pointed to by v,
+// allocating them if necessary, or nil if the struct has no UnknownFields.
+func getUnknownFields(v *reflect.Value) UnknownFields {
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := v.Elem().FieldByName("Unknown")
+	if !field.IsValid() || field.Type() != unknownFieldsType {
+		return nil
+	}
+	if field.IsNil() {
+		field.Set(reflect.MakeMap(unknownFieldsType))
+	}
+	return field.Interface().(UnknownFields)
+}
+
+// readUnknown captures the encoded value of a field into the UnknownFields
+// of v. It returns false if v has nowhere to keep unknown fields.
+func readUnknown(r Reader, v *reflect.Value, e *enc) (bool, error) {
+	unknown := getUnknownFields(v)
+	if unknown == nil {
+		return false, nil
+	}
+	return true, unknown.read(r, *e)
+}
+
+// read captures the encoded value of the field e
+func (u UnknownFields) read(r Reader, e enc) error {
+	var buf bytes.Buffer
+	if err := skipValue(&teeReader{r, &buf}, e.typ); err != nil {
+		return err
+	}
+	u[e] = buf.Bytes()
+	return nil
+}
+
+// teeReader writes everything read from R to W
+type teeReader struct {
+	R Reader
+	W *bytes.Buffer
+}
+
+func (t *teeReader) Len() int { return t.R.Len() }
+
+func (t *teeReader) Read(p []byte) (int, error) {
+	n, err := t.R.Read(p)
+	t.W.Write(p[:n])
+	return n, err
+}
+
+func (t *teeReader) ReadByte() (byte, error) {
+	b, err := t.R.ReadByte()
+	if err == nil {
+		t.W.WriteByte(b)
+	}
+	return b, err
+}
+
+func (t *teeReader) UnreadByte() error {
+	return fmt.Errorf("teeReader: UnreadByte not supported")
+}
+
+var fixedLengths = map[uint8]int64{
+	ST_UINT8:   1,
+	ST_UINT16:  2,
+	ST_UINT32:  4,
+	ST_UINT64:  8,
+	ST_HASH96:  12,
+	ST_HASH128: 16,
+	ST_HASH160: 20,
+	ST_HASH192: 24,
+	ST_HASH256: 32,
+	ST_HASH384: 48,
+	ST_HASH512: 64,
+}
+
+func skipBytes(r Reader, n int64) error {
+	if _, err := io.CopyN(io.Discard, r, n); err != nil {
+		return fmt.Errorf("Short read skipping %d bytes: %s", n, err.Error())
+	}
+	return nil
+}
+
+func skipIssue(r Reader) error {
+	var currency Currency
+	if err := unmarshalSlice(currency[:], r, "Currency"); err != nil {
+		return err
+	}
+	if currency.IsNative() {
+		return nil
+	}
+	return skipBytes(r, 20)
+}
+
+func skipVariableLength(r Reader) error {
+	length, err := readVariableLength(r)
+	if err != nil {
+		return err
+	}
+	return skipBytes(r, int64(length))
+}
+
+// skipValue reads past a single value of the given serialized type
+func skipValue(r Reader, typ uint8) error {
+	if n, ok := fixedLengths[typ]; ok {
+		return skipBytes(r, n)
+	}
+	switch typ {
+	case ST_AMOUNT:
+		first, err := r.ReadByte()
+		if err != nil {
+			return err
+		}
+		if first&0x80 == 0 {
+			return skipBytes(r, 7)
+		}
+		return skipBytes(r, 47)
+	case ST_VL, ST_ACCOUNT, ST_VECTOR256:
+		return skipVariableLength(r)
+	case ST_ISSUE:
+		return skipIssue(r)
+	case ST_XCHAIN_BRIDGE:
+		for i := 0; i < 2; i++ {
+			if err := skipVariableLength(r); err != nil {
+				return err
+			}
+			if err := skipIssue(r); err != nil {
+				return err
+			}
+		}
+		return nil
+	case ST_PATHSET:
+		for {
+			b, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			entry := pathEntry(b)
+			switch entry {
+			case PATH_END:
+				return nil
+			case PATH_BOUNDARY:
+				continue
+			}
+			for _, t := range []pathEntry{PATH_ACCOUNT, PATH_CURRENCY, PATH_ISSUER} {
+				if entry&t > 0 {
+					if err := skipBytes(r, 20); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	case ST_OBJECT, ST_ARRAY:
+		end := reverseEncodings["EndOfObject"]
+		if typ == ST_ARRAY {
+			end = reverseEncodings["EndOfArray"]
+		}
+		for {
+			e, err := readEncoding(r)
+			if err != nil {
+				return err
+			}
+			if *e == end {
+				return nil
+			}
+			if err := skipValue(r, e.typ); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("Cannot skip unknown type: %d", typ)
+	}
+}
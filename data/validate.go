@@ -0,0 +1,721 @@
+package data
+
+import (
+	"fmt"
+	"math/bits"
+	"reflect"
+	"strings"
+)
+
+// ValidationError is a transaction which fails the checks that rippled makes
+// before looking at the ledger. Result is the code rippled would return.
+type ValidationError struct {
+	Result  TransactionResult
+	Message string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Result, e.Message)
+}
+
+func invalid(result TransactionResult, format string, args ...interface{}) error {
+	return &ValidationError{Result: result, Message: fmt.Sprintf(format, args...)}
+}
+
+const (
+	maxTransferRate     = 2000000000
+	minTickSize         = 3
+	maxTickSize         = 15
+	maxDomainLength     = 256
+	maxTicketCount      = 250
+	maxSignerEntries    = 32
+	maxPathSize         = 6
+	maxPathLength       = 8
+	maxTransferFee      = 50000
+	maxTokenURILength   = 256
+	maxTokenOfferCancel = 500
+	maxTradingFee       = 1000
+	maxAuthAccounts     = 4
+	maxOracleDataSeries = 10
+	maxMemoSize         = 1024
+)
+
+// badCurrency is the standard currency code "XRP", which is not allowed for
+// issued currencies
+var badCurrency = Currency{12: 'X', 13: 'R', 14: 'P'}
+
+// txFlags are the flags allowed for each transaction type, in addition to
+// TxCanonicalSignature
+var txFlags = map[TransactionType]TransactionFlag{
+	PAYMENT:              TxNoDirectRipple | TxPartialPayment | TxLimitQuality,
+	ACCOUNT_SET:          TxRequireDestTag | TxOptionalDestTag | TxRequireAuth | TxOptionalAuth | TxDisallowXRP | TxAllowXRP,
+	OFFER_CREATE:         TxPassive | TxImmediateOrCancel | TxFillOrKill | TxSell,
+	TRUST_SET:            TxSetAuth | TxSetNoRipple | TxClearNoRipple | TxSetFreeze | TxClearFreeze,
+	AMENDMENT:            TxGotMajority | TxLostMajority,
+	PAYCHAN_CLAIM:        TxRenew | TxClose,
+	NFTOKEN_MINT:         TxBurnable | TxOnlyXRP | TxTrustLine | TxTransferable,
+	NFTOKEN_CREATE_OFFER: TxSellNFToken,
+	AMM_DEPOSIT:          TxLPToken | TxSingleAsset | TxTwoAsset | TxOneAssetLPToken | TxLimitLPToken | TxTwoAssetIfEmpty,
+	AMM_WITHDRAW:         TxLPToken | TxWithdrawAll | TxOneAssetWithdrawAll | TxSingleAsset | TxTwoAsset | TxOneAssetLPToken | TxLimitLPToken,
+}
+
+// Validate makes the checks that rippled makes in preflight, which depend
+// only on the transaction itself. The error is a *ValidationError with the
+// result rippled would return. Signatures are not checked, so a transaction
+// may be validated before it is signed.
+func Validate(tx Transaction) error {
+	base := tx.GetBase()
+	var flags TransactionFlag
+	if base.Flags != nil {
+		flags = *base.Flags
+	}
+	if flags&^(TxCanonicalSignature|txFlags[base.TransactionType]) != 0 {
+		return invalid(temINVALID_FLAG, "Invalid flags for %s: %08X", base.GetType(), uint32(flags))
+	}
+	switch tx.(type) {
+	case *Amendment, *SetFee, *UNLModify:
+		return validatePseudo(base)
+	}
+	if err := validateBase(tx, base); err != nil {
+		return err
+	}
+	switch v := tx.(type) {
+	case *Payment:
+		return v.validate(flags)
+	case *AccountSet:
+		return v.validate(flags)
+	case *AccountDelete:
+		if v.Destination.Equals(v.Account) {
+			return invalid(temDST_IS_SRC, "Destination is Account")
+		}
+	case *SetRegularKey:
+		if v.RegularKey != nil && Account(*v.RegularKey).Equals(v.Account) {
+			return invalid(temBAD_REGKEY, "RegularKey is the master key")
+		}
+	case *OfferCreate:
+		return v.validate(flags)
+	case *OfferCancel:
+		if v.OfferSequence == 0 {
+			return invalid(temBAD_SEQUENCE, "Missing OfferSequence")
+		}
+	case *TrustSet:
+		return v.validate(flags)
+	case *TicketCreate:
+		if v.TicketCount == nil || *v.TicketCount == 0 || *v.TicketCount > maxTicketCount {
+			return invalid(temINVALID_COUNT, "TicketCount must be from 1 to %d", maxTicketCount)
+		}
+	case *EscrowCreate:
+		return v.validate()
+	case *EscrowFinish:
+		return v.validate()
+	case *SignerListSet:
+		return v.validate()
+	case *PaymentChannelCreate:
+		if err := checkAmount("Amount", &v.Amount, true); err != nil {
+			return err
+		}
+		if v.Destination.Equals(v.Account) {
+			return invalid(temDST_IS_SRC, "Destination is Account")
+		}
+		if !isPublicKey(v.PublicKey[:]) {
+			return invalid(temMALFORMED, "Bad PublicKey")
+		}
+	case *PaymentChannelFund:
+		return checkAmount("Amount", &v.Amount, true)
+	case *PaymentChannelClaim:
+		return v.validate(flags)
+	case *CheckCreate:
+		if v.Destination.Equals(v.Account) {
+			return invalid(temREDUNDANT, "Destination is Account")
+		}
+		if err := checkAmount("SendMax", &v.SendMax, false); err != nil {
+			return err
+		}
+		if v.Expiration != nil && *v.Expiration == 0 {
+			return invalid(temBAD_EXPIRATION, "Expiration is zero")
+		}
+	case *CheckCash:
+		if (v.Amount == nil) == (v.DeliverMin == nil) {
+			return invalid(temMALFORMED, "Exactly one of Amount and DeliverMin is required")
+		}
+		if v.Amount != nil {
+			return checkAmount("Amount", v.Amount, false)
+		}
+		return checkAmount("DeliverMin", v.DeliverMin, false)
+	case *SetDepositPreAuth:
+		if (v.Authorize == nil) == (v.Unauthorize == nil) {
+			return invalid(temMALFORMED, "Exactly one of Authorize and Unauthorize is required")
+		}
+		target := v.Authorize
+		if target == nil {
+			target = v.Unauthorize
+		}
+		switch {
+		case target.IsZero():
+			return invalid(temINVALID_ACCOUNT_ID, "Zero account")
+		case target.Equals(v.Account):
+			return invalid(temCANNOT_PREAUTH_SELF, "Account may not preauthorise itself")
+		}
+	case *NFTokenMint:
+		return v.validate(flags)
+	case *NFTokenCreateOffer:
+		return v.validate(flags)
+	case *NFTCancelOffer:
+		if v.NFTokenOffers == nil || len(*v.NFTokenOffers) == 0 || len(*v.NFTokenOffers) > maxTokenOfferCancel {
+			return invalid(temMALFORMED, "NFTokenOffers must have from 1 to %d entries", maxTokenOfferCancel)
+		}
+		seen := make(map[Hash256]bool)
+		for _, offer := range *v.NFTokenOffers {
+			if seen[offer] {
+				return invalid(temMALFORMED, "Duplicate NFTokenOffer: %s", offer)
+			}
+			seen[offer] = true
+		}
+	case *NFTAcceptOffer:
+		if v.NFTokenBuyOffer == nil && v.NFTokenSellOffer == nil {
+			return invalid(temMALFORMED, "Missing NFTokenBuyOffer and NFTokenSellOffer")
+		}
+		if v.NFTokenBrokerFee != nil {
+			if v.NFTokenBuyOffer == nil || v.NFTokenSellOffer == nil {
+				return invalid(temMALFORMED, "NFTokenBrokerFee requires both offers")
+			}
+			if !isPositive(v.NFTokenBrokerFee) {
+				return invalid(temMALFORMED, "NFTokenBrokerFee must be positive")
+			}
+		}
+	case *Clawback:
+		switch {
+		case v.Amount.Value == nil || v.Amount.IsNative():
+			return invalid(temBAD_AMOUNT, "Amount must be an issued currency")
+		case v.Amount.Issuer.Equals(v.Account) || !isPositive(&v.Amount):
+			return invalid(temBAD_AMOUNT, "Bad Amount: %s", v.Amount)
+		}
+	case *AMMCreate:
+		if v.Amount.Value != nil && v.Amount2.Value != nil &&
+			v.Amount.Currency == v.Amount2.Currency && v.Amount.Issuer == v.Amount2.Issuer {
+			return invalid(temBAD_AMM_TOKENS, "Amount and Amount2 are the same asset")
+		}
+		if err := checkAmount("Amount", &v.Amount, false); err != nil {
+			return err
+		}
+		if err := checkAmount("Amount2", &v.Amount2, false); err != nil {
+			return err
+		}
+		return checkTradingFee(v.TradingFee)
+	case *AMMDeposit:
+		return v.validate(flags)
+	case *AMMWithdraw:
+		return v.validate(flags)
+	case *AMMVote:
+		if err := checkAssetPair(v.Asset, v.Asset2); err != nil {
+			return err
+		}
+		return checkTradingFee(v.TradingFee)
+	case *AMMBid:
+		return v.validate()
+	case *AMMDelete:
+		return checkAssetPair(v.Asset, v.Asset2)
+	case *OracleSet:
+		switch n := len(v.PriceDataSeries); {
+		case n == 0:
+			return invalid(temARRAY_EMPTY, "Empty PriceDataSeries")
+		case n > maxOracleDataSeries:
+			return invalid(temARRAY_TOO_LARGE, "PriceDataSeries has more than %d entries", maxOracleDataSeries)
+		}
+	}
+	return nil
+}
+
+func validateBase(tx Transaction, base *TxBase) error {
+	if base.Account.IsZero() {
+		return invalid(temBAD_SRC_ACCOUNT, "Missing Account")
+	}
+	if !base.Fee.IsNative() || base.Fee.IsNegative() {
+		return invalid(temBAD_FEE, "Fee must be XRP and not negative: %s", base.Fee)
+	}
+	if base.Sequence != 0 {
+		if v := reflect.ValueOf(tx).Elem().FieldByName("TicketSequence"); v.IsValid() && !v.IsNil() {
+			return invalid(temSEQ_AND_TICKET, "Sequence and TicketSequence are both set")
+		}
+	}
+	var memoLength int
+	for _, memo := range base.Memos {
+		memoLength += len(memo.Memo.MemoType) + len(memo.Memo.MemoData) + len(memo.Memo.MemoFormat)
+		if !isMemoSymbols(memo.Memo.MemoType) || !isMemoSymbols(memo.Memo.MemoFormat) {
+			return invalid(temINVALID, "MemoType and MemoFormat must be URL characters")
+		}
+	}
+	if memoLength > maxMemoSize {
+		return invalid(temINVALID, "Memos are longer than %d bytes", maxMemoSize)
+	}
+	return nil
+}
+
+// validatePseudo checks a transaction made by validators, which has no
+// account, fee, sequence or signature
+func validatePseudo(base *TxBase) error {
+	switch {
+	case !base.Account.IsZero():
+		return invalid(temBAD_SRC_ACCOUNT, "Pseudo-transaction has an Account")
+	case !base.Fee.IsZero():
+		return invalid(temBAD_FEE, "Pseudo-transaction has a Fee")
+	case base.SigningPubKey != nil && !base.SigningPubKey.IsZero(),
+		base.TxnSignature != nil && len(*base.TxnSignature) > 0,
+		len(base.Signers) > 0:
+		return invalid(temBAD_SIGNATURE, "Pseudo-transaction is signed")
+	case base.Sequence != 0:
+		return invalid(temBAD_SEQUENCE, "Pseudo-transaction has a Sequence")
+	}
+	return nil
+}
+
+func (p *Payment) validate(flags TransactionFlag) error {
+	if p.Destination.IsZero() {
+		return invalid(temDST_NEEDED, "Missing Destination")
+	}
+	if err := checkAmount("Amount", &p.Amount, false); err != nil {
+		return err
+	}
+	source := p.Amount.Currency
+	if p.SendMax != nil {
+		if err := checkAmount("SendMax", p.SendMax, false); err != nil {
+			return err
+		}
+		source = p.SendMax.Currency
+	}
+	xrpDirect := source.IsNative() && p.Amount.Currency.IsNative()
+	paths := p.Paths != nil
+	switch {
+	case paths && len(*p.Paths) > maxPathSize:
+		return invalid(temMALFORMED, "More than %d paths", maxPathSize)
+	case p.Account.Equals(p.Destination) && source == p.Amount.Currency && !paths:
+		return invalid(temREDUNDANT, "Payment to self without paths")
+	case xrpDirect && p.SendMax != nil:
+		return invalid(temBAD_SEND_XRP_MAX, "SendMax with XRP to XRP")
+	case xrpDirect && paths:
+		return invalid(temBAD_SEND_XRP_PATHS, "Paths with XRP to XRP")
+	case xrpDirect && flags&TxPartialPayment != 0:
+		return invalid(temBAD_SEND_XRP_PARTIAL, "PartialPayment with XRP to XRP")
+	case xrpDirect && flags&TxLimitQuality != 0:
+		return invalid(temBAD_SEND_XRP_LIMIT, "LimitQuality with XRP to XRP")
+	case xrpDirect && flags&TxNoDirectRipple != 0:
+		return invalid(temBAD_SEND_XRP_NO_DIRECT, "NoDirectRipple with XRP to XRP")
+	}
+	if paths {
+		for _, path := range *p.Paths {
+			if len(path) > maxPathLength {
+				return invalid(temMALFORMED, "Path longer than %d steps", maxPathLength)
+			}
+		}
+	}
+	if p.DeliverMin != nil {
+		switch {
+		case flags&TxPartialPayment == 0:
+			return invalid(temBAD_AMOUNT, "DeliverMin without PartialPayment")
+		case p.DeliverMin.Value == nil || !isPositive(p.DeliverMin):
+			return invalid(temBAD_AMOUNT, "DeliverMin must be positive")
+		case p.DeliverMin.IsNative() != p.Amount.IsNative() ||
+			p.DeliverMin.Currency != p.Amount.Currency || p.DeliverMin.Issuer != p.Amount.Issuer:
+			return invalid(temBAD_AMOUNT, "DeliverMin is not in the currency of Amount")
+		case p.Amount.Value.Less(*p.DeliverMin.Value):
+			return invalid(temBAD_AMOUNT, "DeliverMin exceeds Amount")
+		}
+	}
+	return nil
+}
+
+func (a *AccountSet) validate(flags TransactionFlag) error {
+	var set, clear uint32
+	if a.SetFlag != nil {
+		set = *a.SetFlag
+	}
+	if a.ClearFlag != nil {
+		clear = *a.ClearFlag
+	}
+	if set != 0 && set == clear {
+		return invalid(temINVALID_FLAG, "SetFlag and ClearFlag are the same")
+	}
+	for _, pair := range []struct {
+		setFlag, clearFlag TransactionFlag
+		asf                TransactionFlag
+	}{
+		{TxRequireAuth, TxOptionalAuth, TxSetRequireAuth},
+		{TxRequireDestTag, TxOptionalDestTag, TxSetRequireDest},
+		{TxDisallowXRP, TxAllowXRP, TxSetDisallowXRP},
+	} {
+		setting := flags&pair.setFlag != 0 || set == uint32(pair.asf)
+		clearing := flags&pair.clearFlag != 0 || clear == uint32(pair.asf)
+		if setting && clearing {
+			return invalid(temINVALID_FLAG, "Flag %d is both set and cleared", pair.asf)
+		}
+	}
+	if a.TransferRate != nil && *a.TransferRate != 0 && (*a.TransferRate < 1000000000 || *a.TransferRate > maxTransferRate) {
+		return invalid(temBAD_TRANSFER_RATE, "Bad TransferRate: %d", *a.TransferRate)
+	}
+	if a.TickSize != nil && *a.TickSize != 0 && (*a.TickSize < minTickSize || *a.TickSize > maxTickSize) {
+		return invalid(temBAD_TICK_SIZE, "Bad TickSize: %d", *a.TickSize)
+	}
+	if a.MessageKey != nil && len(*a.MessageKey) > 0 && !isPublicKey(*a.MessageKey) {
+		return invalid(telBAD_PUBLIC_KEY, "Bad MessageKey")
+	}
+	if a.Domain != nil && len(*a.Domain) > maxDomainLength {
+		return invalid(telBAD_DOMAIN, "Domain is longer than %d bytes", maxDomainLength)
+	}
+	return nil
+}
+
+func (o *OfferCreate) validate(flags TransactionFlag) error {
+	switch {
+	case flags&TxImmediateOrCancel != 0 && flags&TxFillOrKill != 0:
+		return invalid(temINVALID_FLAG, "ImmediateOrCancel and FillOrKill are both set")
+	case o.Expiration != nil && *o.Expiration == 0:
+		return invalid(temBAD_EXPIRATION, "Expiration is zero")
+	case o.OfferSequence != nil && *o.OfferSequence == 0:
+		return invalid(temBAD_SEQUENCE, "OfferSequence is zero")
+	case o.TakerPays.Value == nil || o.TakerGets.Value == nil:
+		return invalid(temBAD_AMOUNT, "Missing TakerPays or TakerGets")
+	case o.TakerPays.IsNative() && o.TakerGets.IsNative():
+		return invalid(temBAD_OFFER, "XRP for XRP offer")
+	case !isPositive(&o.TakerPays) || !isPositive(&o.TakerGets):
+		return invalid(temBAD_OFFER, "TakerPays and TakerGets must be positive")
+	case o.TakerPays.Currency == o.TakerGets.Currency && o.TakerPays.Issuer == o.TakerGets.Issuer:
+		return invalid(temREDUNDANT, "TakerPays and TakerGets are the same asset")
+	case !isCurrency(&o.TakerPays) || !isCurrency(&o.TakerGets):
+		return invalid(temBAD_CURRENCY, "Bad currency")
+	case o.TakerPays.IsNative() != o.TakerPays.Issuer.IsZero(),
+		o.TakerGets.IsNative() != o.TakerGets.Issuer.IsZero():
+		return invalid(temBAD_ISSUER, "Bad issuer")
+	}
+	return nil
+}
+
+func (t *TrustSet) validate(flags TransactionFlag) error {
+	limit := t.LimitAmount
+	switch {
+	case flags&TxSetFreeze != 0 && flags&TxClearFreeze != 0:
+		return invalid(temINVALID_FLAG, "SetFreeze and ClearFreeze are both set")
+	case limit.Value == nil:
+		return invalid(temBAD_AMOUNT, "Missing LimitAmount")
+	case limit.IsNative():
+		return invalid(temBAD_LIMIT, "LimitAmount is XRP")
+	case !isCurrency(&limit):
+		return invalid(temBAD_CURRENCY, "Bad currency")
+	case limit.IsNegative():
+		return invalid(temBAD_LIMIT, "LimitAmount is negative")
+	case limit.Issuer.IsZero():
+		return invalid(temDST_NEEDED, "LimitAmount has no issuer")
+	case limit.Issuer.Equals(t.Account):
+		return invalid(temDST_IS_SRC, "Trust line to self")
+	}
+	return nil
+}
+
+func (e *EscrowCreate) validate() error {
+	if err := checkAmount("Amount", &e.Amount, true); err != nil {
+		return err
+	}
+	switch {
+	case e.CancelAfter == nil && e.FinishAfter == nil:
+		return invalid(temBAD_EXPIRATION, "Missing CancelAfter and FinishAfter")
+	case e.CancelAfter != nil && e.FinishAfter != nil && *e.CancelAfter <= *e.FinishAfter:
+		return invalid(temBAD_EXPIRATION, "CancelAfter is not after FinishAfter")
+	case e.FinishAfter == nil && e.Condition == nil:
+		return invalid(temMALFORMED, "Missing FinishAfter and Condition")
+	}
+	if e.Condition != nil {
+		if _, err := ParseCondition(*e.Condition); err != nil {
+			return invalid(temMALFORMED, "%s", err)
+		}
+	}
+	return nil
+}
+
+// validate also checks that the fulfillment fulfills the condition. rippled
+// makes that check later, with the result tecCRYPTOCONDITION_ERROR.
+func (e *EscrowFinish) validate() error {
+	if (e.Condition == nil) != (e.Fulfillment == nil) {
+		return invalid(temMALFORMED, "Condition and Fulfillment must be given together")
+	}
+	if e.Fulfillment == nil {
+		return nil
+	}
+	if _, err := ParseFulfillment(*e.Fulfillment); err != nil {
+		return invalid(temMALFORMED, "%s", err)
+	}
+	if err := VerifyFulfillment(*e.Condition, *e.Fulfillment); err != nil {
+		return invalid(tecCRYPTOCONDITION_ERROR, "%s", err)
+	}
+	return nil
+}
+
+func (s *SignerListSet) validate() error {
+	switch {
+	case s.SignerQuorum == 0 && len(s.SignerEntries) == 0:
+		// Deletes the signer list
+		return nil
+	case s.SignerQuorum == 0 || len(s.SignerEntries) == 0:
+		return invalid(temMALFORMED, "SignerQuorum and SignerEntries must be given together")
+	case len(s.SignerEntries) > maxSignerEntries:
+		return invalid(temMALFORMED, "More than %d SignerEntries", maxSignerEntries)
+	}
+	seen := make(map[Account]bool)
+	var total uint64
+	for _, entry := range s.SignerEntries {
+		account, weight := entry.SignerEntry.Account, entry.SignerEntry.SignerWeight
+		switch {
+		case account == nil:
+			return invalid(temBAD_SIGNER, "SignerEntry has no Account")
+		case seen[*account]:
+			return invalid(temBAD_SIGNER, "Duplicate signer: %s", account)
+		case account.Equals(s.Account):
+			return invalid(temBAD_SIGNER, "Account may not be its own signer")
+		case weight == nil || *weight == 0:
+			return invalid(temBAD_WEIGHT, "Signer %s has no weight", account)
+		}
+		seen[*account] = true
+		total += uint64(*weight)
+	}
+	if total < uint64(s.SignerQuorum) {
+		return invalid(temBAD_QUORUM, "Signer weight %d is below quorum: %d", total, s.SignerQuorum)
+	}
+	return nil
+}
+
+func (p *PaymentChannelClaim) validate(flags TransactionFlag) error {
+	if p.Balance != nil {
+		if err := checkAmount("Balance", p.Balance, true); err != nil {
+			return err
+		}
+	}
+	if p.Amount != nil {
+		if err := checkAmount("Amount", p.Amount, true); err != nil {
+			return err
+		}
+	}
+	if p.Balance != nil && p.Amount != nil && p.Amount.Value.Less(*p.Balance.Value) {
+		return invalid(temBAD_AMOUNT, "Balance exceeds Amount")
+	}
+	if flags&TxRenew != 0 && flags&TxClose != 0 {
+		return invalid(temMALFORMED, "Renew and Close are both set")
+	}
+	if p.Signature == nil {
+		return nil
+	}
+	if p.PublicKey == nil || p.Balance == nil {
+		return invalid(temMALFORMED, "Signature requires PublicKey and Balance")
+	}
+	if !isPublicKey(p.PublicKey[:]) {
+		return invalid(temMALFORMED, "Bad PublicKey")
+	}
+	authorised := p.Balance
+	if p.Amount != nil {
+		authorised = p.Amount
+	}
+	claim := &ChannelClaim{Channel: p.Channel, Amount: *authorised, Signature: *p.Signature}
+	if ok, err := VerifyChannelClaim(*p.PublicKey, claim); err != nil || !ok {
+		return invalid(temBAD_SIGNATURE, "Bad claim signature")
+	}
+	return nil
+}
+
+func (n *NFTokenMint) validate(flags TransactionFlag) error {
+	switch {
+	case n.NFTokenTaxon == nil:
+		return invalid(temMALFORMED, "Missing NFTokenTaxon")
+	case n.TransferFee != nil && *n.TransferFee > maxTransferFee:
+		return invalid(temBAD_NFTOKEN_TRANSFER_FEE, "TransferFee is above %d", maxTransferFee)
+	case n.TransferFee != nil && *n.TransferFee != 0 && flags&TxTransferable == 0:
+		return invalid(temMALFORMED, "TransferFee without Transferable")
+	case n.Issuer != nil && n.Issuer.Equals(n.Account):
+		return invalid(temMALFORMED, "Issuer is Account")
+	case n.URI != nil && (len(*n.URI) == 0 || len(*n.URI) > maxTokenURILength):
+		return invalid(temMALFORMED, "URI must be from 1 to %d bytes", maxTokenURILength)
+	}
+	return nil
+}
+
+func (n *NFTokenCreateOffer) validate(flags TransactionFlag) error {
+	sell := flags&TxSellNFToken != 0
+	switch {
+	case n.NFTokenID == nil:
+		return invalid(temMALFORMED, "Missing NFTokenID")
+	case n.Amount == nil || n.Amount.Value == nil:
+		return invalid(temBAD_AMOUNT, "Missing Amount")
+	case n.Amount.IsNegative():
+		return invalid(temBAD_AMOUNT, "Amount is negative")
+	case n.Amount.IsZero() && (!sell || !n.Amount.IsNative()):
+		return invalid(temBAD_AMOUNT, "Amount is zero")
+	case n.Expiration != nil && *n.Expiration == 0:
+		return invalid(temBAD_EXPIRATION, "Expiration is zero")
+	case (n.Owner != nil) == sell:
+		return invalid(temMALFORMED, "Owner is required for buy offers and not allowed for sell offers")
+	case n.Owner != nil && n.Owner.Equals(n.Account):
+		return invalid(temMALFORMED, "Owner is Account")
+	case n.Destination != nil && n.Destination.Equals(n.Account):
+		return invalid(temMALFORMED, "Destination is Account")
+	}
+	return nil
+}
+
+// ammField is whether a mode of AMMDeposit or AMMWithdraw takes a field
+type ammField uint8
+
+const (
+	ammForbidden ammField = iota
+	ammRequired
+	ammOptional
+)
+
+// ammFields checks that the amounts of an AMMDeposit or AMMWithdraw are
+// those taken by its mode, given by a single flag. As in rippled, an
+// optional Amount and Amount2 must be both present or both absent.
+func ammFields(flags TransactionFlag, modes map[TransactionFlag][4]ammField, amounts ...*Amount) error {
+	mode := flags &^ TxCanonicalSignature
+	if bits.OnesCount32(uint32(mode)) != 1 {
+		return invalid(temMALFORMED, "Exactly one mode flag is required")
+	}
+	fields := modes[mode]
+	for i, field := range fields {
+		if field != ammOptional && (field == ammRequired) != (amounts[i] != nil) {
+			return invalid(temMALFORMED, "Wrong fields for mode: %08X", uint32(mode))
+		}
+	}
+	if fields[0] == ammOptional && fields[1] == ammOptional && (amounts[0] == nil) != (amounts[1] == nil) {
+		return invalid(temMALFORMED, "Amount and Amount2 must both be present or both absent")
+	}
+	for _, amount := range amounts {
+		if amount != nil {
+			if err := checkAmount("Amount", amount, false); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// The fields of each mode are Amount, Amount2, EPrice and LP tokens. A
+// deposit may give minimums as optional fields.
+var (
+	ammDepositModes = map[TransactionFlag][4]ammField{
+		TxLPToken:         {ammOptional, ammOptional, ammForbidden, ammRequired},
+		TxSingleAsset:     {ammRequired, ammForbidden, ammForbidden, ammOptional},
+		TxTwoAsset:        {ammRequired, ammRequired, ammForbidden, ammOptional},
+		TxOneAssetLPToken: {ammRequired, ammForbidden, ammForbidden, ammRequired},
+		TxLimitLPToken:    {ammRequired, ammForbidden, ammRequired, ammForbidden},
+		TxTwoAssetIfEmpty: {ammRequired, ammRequired, ammForbidden, ammForbidden},
+	}
+	ammWithdrawModes = map[TransactionFlag][4]ammField{
+		TxLPToken:             {ammForbidden, ammForbidden, ammForbidden, ammRequired},
+		TxWithdrawAll:         {ammForbidden, ammForbidden, ammForbidden, ammForbidden},
+		TxOneAssetWithdrawAll: {ammRequired, ammForbidden, ammForbidden, ammForbidden},
+		TxSingleAsset:         {ammRequired, ammForbidden, ammForbidden, ammForbidden},
+		TxTwoAsset:            {ammRequired, ammRequired, ammForbidden, ammForbidden},
+		TxOneAssetLPToken:     {ammRequired, ammForbidden, ammForbidden, ammRequired},
+		TxLimitLPToken:        {ammRequired, ammForbidden, ammRequired, ammForbidden},
+	}
+)
+
+func (a *AMMDeposit) validate(flags TransactionFlag) error {
+	if err := checkAssetPair(a.Asset, a.Asset2); err != nil {
+		return err
+	}
+	if err := ammFields(flags, ammDepositModes, a.Amount, a.Amount2, a.EPrice, a.LPTokenOut); err != nil {
+		return err
+	}
+	if a.TradingFee != nil {
+		if flags&TxTwoAssetIfEmpty == 0 {
+			return invalid(temMALFORMED, "TradingFee requires TwoAssetIfEmpty")
+		}
+		return checkTradingFee(*a.TradingFee)
+	}
+	return nil
+}
+
+func (a *AMMWithdraw) validate(flags TransactionFlag) error {
+	if err := checkAssetPair(a.Asset, a.Asset2); err != nil {
+		return err
+	}
+	return ammFields(flags, ammWithdrawModes, a.Amount, a.Amount2, a.EPrice, a.LPTokenIn)
+}
+
+func (a *AMMBid) validate() error {
+	if err := checkAssetPair(a.Asset, a.Asset2); err != nil {
+		return err
+	}
+	for name, amount := range map[string]*Amount{"BidMin": a.BidMin, "BidMax": a.BidMax} {
+		if amount != nil {
+			if err := checkAmount(name, amount, false); err != nil {
+				return err
+			}
+		}
+	}
+	if len(a.AuthAccounts) > maxAuthAccounts {
+		return invalid(temMALFORMED, "More than %d AuthAccounts", maxAuthAccounts)
+	}
+	seen := make(map[Account]bool)
+	for _, auth := range a.AuthAccounts {
+		account := auth.AuthAccount.Account
+		if account.Equals(a.Account) || seen[account] {
+			return invalid(temMALFORMED, "Bad AuthAccount: %s", account)
+		}
+		seen[account] = true
+	}
+	return nil
+}
+
+// checkAmount checks that an amount is present and positive, is not in the
+// currency "XRP" if issued, and is XRP if native is set
+func checkAmount(name string, amount *Amount, native bool) error {
+	switch {
+	case amount == nil || amount.Value == nil:
+		return invalid(temBAD_AMOUNT, "Missing %s", name)
+	case native && !amount.IsNative():
+		return invalid(temBAD_AMOUNT, "%s must be XRP", name)
+	case !isPositive(amount):
+		return invalid(temBAD_AMOUNT, "%s must be positive: %s", name, amount)
+	case !isCurrency(amount):
+		return invalid(temBAD_CURRENCY, "%s has bad currency", name)
+	}
+	return nil
+}
+
+// isCurrency reports whether an issued amount has a currency other than XRP
+func isCurrency(amount *Amount) bool {
+	return amount.IsNative() || !(amount.Currency.IsNative() || amount.Currency == badCurrency)
+}
+
+func isPositive(amount *Amount) bool {
+	return !amount.IsNegative() && !amount.IsZero()
+}
+
+func checkTradingFee(fee uint16) error {
+	if fee > maxTradingFee {
+		return invalid(temBAD_FEE, "TradingFee is above %d", maxTradingFee)
+	}
+	return nil
+}
+
+func checkAssetPair(a, b Asset) error {
+	if a == b {
+		return invalid(temBAD_AMM_TOKENS, "Asset and Asset2 are the same")
+	}
+	return nil
+}
+
+// isPublicKey reports whether b is a compressed secp256k1 or an Ed25519 key
+func isPublicKey(b []byte) bool {
+	return len(b) == 33 && (b[0] == 0x02 || b[0] == 0x03 || b[0] == 0xED)
+}
+
+// isMemoSymbols reports whether b contains only characters allowed in URLs
+func isMemoSymbols(b []byte) bool {
+	for _, c := range b {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("-._~:/?#[]@!$&'()*+,;=%", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
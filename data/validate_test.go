@@ -0,0 +1,134 @@
+package data
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const (
+	validateAccount     = `"Account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Fee": "10", "Sequence": 1`
+	validateDestination = "rb1fWuuAEtPUaeEWxocV3h4x5JwDTFZzH"
+	validateIssuer      = "rvYAfWj5gh67oV6fW32ZzP3Aw4Eubs59B"
+	validateUSD         = `{"value": "10", "currency": "USD", "issuer": "` + validateIssuer + `"}`
+	validateLPT         = `{"value": "10", "currency": "039C99CD9AB0B70B32ECDA51EAAE471625608EA2", "issuer": "` + validateIssuer + `"}`
+	validateAMM         = `"Asset": {"currency": "XRP"}, "Asset2": {"currency": "USD", "issuer": "` + validateIssuer + `"}`
+)
+
+var validateTests = []struct {
+	tx     string
+	result string
+}{
+	// Common fields
+	{`"TransactionType": "Payment", "Destination": "` + validateDestination + `", "Amount": "1000"`, ""},
+	{`"TransactionType": "Payment", "Flags": 131072, "Destination": "` + validateDestination + `", "Amount": ` + validateUSD, ""},
+	{`"TransactionType": "Payment", "Flags": 1, "Destination": "` + validateDestination + `", "Amount": "1000"`, "temINVALID_FLAG"},
+	{`"TransactionType": "Payment", "TicketSequence": 5, "Destination": "` + validateDestination + `", "Amount": "1000"`, "temSEQ_AND_TICKET"},
+	{`"TransactionType": "Payment", "Fee": "-10", "Destination": "` + validateDestination + `", "Amount": "1000"`, "temBAD_FEE"},
+	{`"TransactionType": "Payment", "Memos": [{"Memo": {"MemoType": "20"}}], "Destination": "` + validateDestination + `", "Amount": "1000"`, "temINVALID"},
+
+	// Payment
+	{`"TransactionType": "Payment", "Amount": "1000"`, "temDST_NEEDED"},
+	{`"TransactionType": "Payment", "Destination": "` + validateDestination + `", "Amount": "0"`, "temBAD_AMOUNT"},
+	{`"TransactionType": "Payment", "Destination": "` + validateDestination + `", "Amount": {"value": "10", "currency": "XRP", "issuer": "` + validateIssuer + `"}`, "temBAD_CURRENCY"},
+	{`"TransactionType": "Payment", "Destination": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "Amount": "1000"`, "temREDUNDANT"},
+	{`"TransactionType": "Payment", "Destination": "` + validateDestination + `", "Amount": "1000", "SendMax": "1000"`, "temBAD_SEND_XRP_MAX"},
+	{`"TransactionType": "Payment", "Flags": 131072, "Destination": "` + validateDestination + `", "Amount": "1000"`, "temBAD_SEND_XRP_PARTIAL"},
+	{`"TransactionType": "Payment", "Flags": 65536, "Destination": "` + validateDestination + `", "Amount": "1000"`, "temBAD_SEND_XRP_NO_DIRECT"},
+	{`"TransactionType": "Payment", "Destination": "` + validateDestination + `", "Amount": ` + validateUSD + `, "DeliverMin": ` + validateUSD, "temBAD_AMOUNT"},
+	{`"TransactionType": "Payment", "Flags": 131072, "Destination": "` + validateDestination + `", "Amount": ` + validateUSD + `, "DeliverMin": {"value": "11", "currency": "USD", "issuer": "` + validateIssuer + `"}`, "temBAD_AMOUNT"},
+
+	// AccountSet
+	{`"TransactionType": "AccountSet", "SetFlag": 8, "TransferRate": 1002000000, "TickSize": 5`, ""},
+	{`"TransactionType": "AccountSet", "SetFlag": 8, "ClearFlag": 8`, "temINVALID_FLAG"},
+	{`"TransactionType": "AccountSet", "Flags": 262144, "ClearFlag": 2`, "temINVALID_FLAG"},
+	{`"TransactionType": "AccountSet", "TransferRate": 999999999`, "temBAD_TRANSFER_RATE"},
+	{`"TransactionType": "AccountSet", "TickSize": 2`, "temBAD_TICK_SIZE"},
+
+	// OfferCreate
+	{`"TransactionType": "OfferCreate", "TakerPays": "1000", "TakerGets": ` + validateUSD, ""},
+	{`"TransactionType": "OfferCreate", "Flags": 393216, "TakerPays": "1000", "TakerGets": ` + validateUSD, "temINVALID_FLAG"},
+	{`"TransactionType": "OfferCreate", "TakerPays": "1000", "TakerGets": "1000"`, "temBAD_OFFER"},
+	{`"TransactionType": "OfferCreate", "TakerPays": ` + validateUSD + `, "TakerGets": ` + validateUSD, "temREDUNDANT"},
+	{`"TransactionType": "OfferCreate", "Expiration": 0, "TakerPays": "1000", "TakerGets": ` + validateUSD, "temBAD_EXPIRATION"},
+
+	// TrustSet
+	{`"TransactionType": "TrustSet", "LimitAmount": ` + validateUSD, ""},
+	{`"TransactionType": "TrustSet", "LimitAmount": "1000"`, "temBAD_LIMIT"},
+	{`"TransactionType": "TrustSet", "LimitAmount": {"value": "-1", "currency": "USD", "issuer": "` + validateIssuer + `"}`, "temBAD_LIMIT"},
+	{`"TransactionType": "TrustSet", "LimitAmount": {"value": "1", "currency": "USD", "issuer": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"}`, "temDST_IS_SRC"},
+
+	// Others
+	{`"TransactionType": "OfferCancel"`, "temBAD_SEQUENCE"},
+	{`"TransactionType": "AccountDelete", "Destination": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`, "temDST_IS_SRC"},
+	{`"TransactionType": "SetRegularKey", "RegularKey": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`, "temBAD_REGKEY"},
+	{`"TransactionType": "TicketCreate", "Sequence": 0, "TicketCount": 251`, "temINVALID_COUNT"},
+	{`"TransactionType": "EscrowCreate", "Destination": "` + validateDestination + `", "Amount": "1000", "FinishAfter": 10, "CancelAfter": 20`, ""},
+	{`"TransactionType": "EscrowCreate", "Destination": "` + validateDestination + `", "Amount": "1000", "FinishAfter": 20, "CancelAfter": 20`, "temBAD_EXPIRATION"},
+	{`"TransactionType": "EscrowCreate", "Destination": "` + validateDestination + `", "Amount": "1000", "CancelAfter": 20`, "temMALFORMED"},
+	{`"TransactionType": "EscrowFinish", "Owner": "` + validateDestination + `", "OfferSequence": 1, "Fulfillment": "A0028000"`, "temMALFORMED"},
+	{`"TransactionType": "EscrowFinish", "Owner": "` + validateDestination + `", "OfferSequence": 1, "Fulfillment": "A0028000", "Condition": "A0258020E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855810100"`, ""},
+	{`"TransactionType": "EscrowFinish", "Owner": "` + validateDestination + `", "OfferSequence": 1, "Fulfillment": "A003800100", "Condition": "A0258020E3B0C44298FC1C149AFBF4C8996FB92427AE41E4649B934CA495991B7852B855810100"`, "tecCRYPTOCONDITION_ERROR"},
+	{`"TransactionType": "SignerListSet", "SignerQuorum": 2, "SignerEntries": [{"SignerEntry": {"Account": "` + validateDestination + `", "SignerWeight": 1}}]`, "temBAD_QUORUM"},
+	{`"TransactionType": "SignerListSet", "SignerQuorum": 1, "SignerEntries": [{"SignerEntry": {"Account": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "SignerWeight": 1}}]`, "temBAD_SIGNER"},
+	{`"TransactionType": "SignerListSet", "SignerQuorum": 1`, "temMALFORMED"},
+	{`"TransactionType": "SignerListSet"`, ""},
+	{`"TransactionType": "PaymentChannelFund", "Channel": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3", "Amount": ` + validateUSD, "temBAD_AMOUNT"},
+	{`"TransactionType": "PaymentChannelClaim", "Channel": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3", "Balance": "2000", "Amount": "1000"`, "temBAD_AMOUNT"},
+	{`"TransactionType": "PaymentChannelClaim", "Flags": 196608, "Channel": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3"`, "temMALFORMED"},
+	{`"TransactionType": "CheckCreate", "Destination": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", "SendMax": "1000"`, "temREDUNDANT"},
+	{`"TransactionType": "CheckCash", "CheckID": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3", "Amount": "1000", "DeliverMin": "1000"`, "temMALFORMED"},
+	{`"TransactionType": "DepositPreauth", "Authorize": "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh"`, "temCANNOT_PREAUTH_SELF"},
+	{`"TransactionType": "NFTokenMint", "NFTokenTaxon": 0, "TransferFee": 50001, "Flags": 8`, "temBAD_NFTOKEN_TRANSFER_FEE"},
+	{`"TransactionType": "NFTokenMint", "NFTokenTaxon": 0, "TransferFee": 100`, "temMALFORMED"},
+	{`"TransactionType": "NFTokenCreateOffer", "NFTokenID": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3", "Amount": "0", "Flags": 1`, ""},
+	{`"TransactionType": "NFTokenCreateOffer", "NFTokenID": "5DB01B7FFED6B67E6B0414DED11E051D2EE2B7619CE0EAA6286D67A3A4D5BDB3", "Amount": "1000"`, "temMALFORMED"},
+	{`"TransactionType": "NFTokenAcceptOffer"`, "temMALFORMED"},
+	{`"TransactionType": "Clawback", "Amount": "1000"`, "temBAD_AMOUNT"},
+	{`"TransactionType": "AMMCreate", "Amount": "1000", "Amount2": ` + validateUSD + `, "TradingFee": 1001`, "temBAD_FEE"},
+	{`"TransactionType": "AMMCreate", "Amount": ` + validateUSD + `, "Amount2": ` + validateUSD + `, "TradingFee": 10`, "temBAD_AMM_TOKENS"},
+	{`"TransactionType": "AMMDeposit", "Flags": 65536, ` + validateAMM + `, "LPTokenOut": ` + validateLPT, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 65536, ` + validateAMM + `, "LPTokenOut": ` + validateLPT + `, "Amount": "1000", "Amount2": ` + validateUSD, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 65536, ` + validateAMM + `, "LPTokenOut": ` + validateLPT + `, "Amount": "1000"`, "temMALFORMED"},
+	{`"TransactionType": "AMMDeposit", "Flags": 65536, ` + validateAMM + `, "LPTokenOut": ` + validateLPT + `, "Amount2": ` + validateUSD, "temMALFORMED"},
+	{`"TransactionType": "AMMDeposit", "Flags": 65536, ` + validateAMM + `, "LPTokenOut": ` + validateLPT + `, "EPrice": "1000"`, "temMALFORMED"},
+	{`"TransactionType": "AMMDeposit", "Flags": 524288, ` + validateAMM + `, "Amount": "1000"`, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 524288, ` + validateAMM + `, "Amount": "1000", "LPTokenOut": ` + validateLPT, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 524288, ` + validateAMM + `, "Amount": "1000", "Amount2": ` + validateUSD, "temMALFORMED"},
+	{`"TransactionType": "AMMDeposit", "Flags": 1048576, ` + validateAMM + `, "Amount": "1000", "Amount2": ` + validateUSD, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 1048576, ` + validateAMM + `, "Amount": "1000", "Amount2": ` + validateUSD + `, "LPTokenOut": ` + validateLPT, ""},
+	{`"TransactionType": "AMMDeposit", "Flags": 1048576, ` + validateAMM + `, "Amount": "1000"`, "temMALFORMED"},
+	{`"TransactionType": "AMMWithdraw", "Flags": 65536, ` + validateAMM + `, "LPTokenIn": ` + validateLPT, ""},
+	{`"TransactionType": "AMMWithdraw", "Flags": 65536, ` + validateAMM + `, "LPTokenIn": ` + validateLPT + `, "Amount": "1000", "Amount2": ` + validateUSD, "temMALFORMED"},
+	{`"TransactionType": "AMMWithdraw", "Flags": 524288, ` + validateAMM + `, "Amount": "1000", "LPTokenIn": ` + validateLPT, "temMALFORMED"},
+
+	// Pseudo-transactions
+	{`"TransactionType": "EnableAmendment", "Account": "rrrrrrrrrrrrrrrrrrrrrhoLvTp", "Fee": "0", "Sequence": 0`, ""},
+	{`"TransactionType": "EnableAmendment", "Account": "rrrrrrrrrrrrrrrrrrrrrhoLvTp", "Fee": "10", "Sequence": 0`, "temBAD_FEE"},
+}
+
+func TestValidate(t *testing.T) {
+	for _, test := range validateTests {
+		s := "{" + validateAccount + ", " + test.tx + "}"
+		var typ struct{ TransactionType string }
+		if err := json.Unmarshal([]byte(s), &typ); err != nil {
+			t.Fatalf("%s: %s", test.tx, err)
+		}
+		factory := GetTxFactoryByType(typ.TransactionType)
+		if factory == nil {
+			t.Fatalf("Unknown TransactionType: %s", typ.TransactionType)
+		}
+		tx := factory()
+		if err := json.Unmarshal([]byte(s), tx); err != nil {
+			t.Fatalf("%s: %s", test.tx, err)
+		}
+		err := Validate(tx)
+		switch {
+		case test.result == "" && err != nil:
+			t.Errorf("%s: %s", test.tx, err)
+		case test.result != "" && err == nil:
+			t.Errorf("%s: expected %s", test.tx, test.result)
+		case test.result != "" && err.(*ValidationError).Result.String() != test.result:
+			t.Errorf("%s: expected %s got: %s", test.tx, test.result, err)
+		}
+	}
+}
@@ -335,6 +335,101 @@ func (num Value) Divide(den Value) (*Value, error) {
 	return v, v.canonicalise()
 }
 
+// MulRound multiplies a by b as rippled's mulRound does, returning a native
+// Value in drips when native is set. The result is rounded towards positive
+// infinity when roundUp is set and towards negative infinity otherwise, and
+// a positive result rounded up is never zero.
+func (a Value) MulRound(b Value, native, roundUp bool) (*Value, error) {
+	if a.IsZero() || b.IsZero() {
+		return newValue(native, false, 0, 0), nil
+	}
+	if a.IsNative() && b.IsNative() && native {
+		return a.Multiply(b)
+	}
+	av, bv, ao, bo := normalise(a, b)
+	negative := a.negative != b.negative
+	var round uint64
+	if negative != roundUp {
+		round = tenTo14m1
+	}
+	// 10^16 <= product <= 10^18
+	product := muldivRound(av, bv, tenTo14, round)
+	return roundedValue(native, negative, roundUp, product, ao+bo+14)
+}
+
+// DivRound divides num by den as rippled's divRound does, rounding as
+// MulRound does.
+func (num Value) DivRound(den Value, native, roundUp bool) (*Value, error) {
+	if den.IsZero() {
+		return nil, fmt.Errorf("Division by zero")
+	}
+	if num.IsZero() {
+		return newValue(native, false, 0, 0), nil
+	}
+	av, bv, ao, bo := normalise(num, den)
+	negative := num.negative != den.negative
+	var round uint64
+	if negative != roundUp {
+		round = bv - 1
+	}
+	// 10^16 <= quotient <= 10^18
+	quotient := muldivRound(av, tenTo17, bv, round)
+	return roundedValue(native, negative, roundUp, quotient, ao-bo-17)
+}
+
+// muldivRound returns (a*b+round)/c, which must fit in a uint64
+func muldivRound(a, b, c, round uint64) uint64 {
+	m := big.NewInt(0).SetUint64(a)
+	m.Mul(m, big.NewInt(0).SetUint64(b))
+	m.Add(m, big.NewInt(0).SetUint64(round))
+	return m.Div(m, big.NewInt(0).SetUint64(c)).Uint64()
+}
+
+// roundedValue canonicalises the result of MulRound or DivRound, first
+// rounding away from zero when the direction of rounding requires it
+func roundedValue(native, negative, roundUp bool, num uint64, offset int64) (*Value, error) {
+	if negative != roundUp {
+		num, offset = canonicaliseRound(native, num, offset)
+	}
+	v := newValue(native, negative, num, offset)
+	if err := v.canonicalise(); err != nil {
+		return nil, err
+	}
+	if roundUp && !negative && v.IsZero() {
+		if native {
+			return NewNativeValue(1)
+		}
+		return NewNonNativeValue(int64(minValue), minOffset)
+	}
+	return v, nil
+}
+
+// canonicaliseRound drops the digits which canonicalise would truncate,
+// rounding the last one away from zero. Like rippled, a native value only
+// rounds up for the last two digits dropped.
+func canonicaliseRound(native bool, num uint64, offset int64) (uint64, int64) {
+	switch {
+	case native && offset < 0:
+		loops := 0
+		for ; offset < -1; offset++ {
+			num /= 10
+			loops++
+		}
+		if loops >= 2 {
+			num += 9
+		} else {
+			num += 10
+		}
+		return num / 10, offset + 1
+	case !native && num > maxValue:
+		for ; num > 10*maxValue; offset++ {
+			num /= 10
+		}
+		return (num + 9) / 10, offset + 1
+	}
+	return num, offset
+}
+
 // Ratio returns the ratio a/b. XRP are interpreted at face value rather than drips.
 // The result of Ratio is always a non-native Value for additional precision.
 func (a Value) Ratio(b Value) (*Value, error) {
@@ -178,6 +178,30 @@ var valueTests = TestSlice{
 	{divValCheck("n-1000000", "2").String(), Equals, "-0.5", "n-1000000/2"},
 	{divValCheck("1", "n-200000000").String(), Equals, "-0.000000005", "1/n-200000000"},
 
+	{divValCheck("2", "3").String(), Equals, "0.6666666666666667", "2/3"},
+	{divRoundValCheck("2", "3", false, false).String(), Equals, "0.6666666666666666", "2/3 round down"},
+	{divRoundValCheck("2", "3", false, true).String(), Equals, "0.6666666666666667", "2/3 round up"},
+	{divRoundValCheck("1", "3", false, true).String(), Equals, "0.3333333333333334", "1/3 round up"},
+	{divRoundValCheck("-1", "3", false, false).String(), Equals, "-0.3333333333333334", "-1/3 round down"},
+	{divRoundValCheck("-1", "3", false, true).String(), Equals, "-0.3333333333333333", "-1/3 round up"},
+	{divRoundValCheck("n1", "3", true, false).String(), Equals, "0", "n1/3 round down"},
+	{divRoundValCheck("n1", "3", true, true).String(), Equals, "0.000001", "n1/3 round up"},
+	{divRoundValCheck("n1000000", "3", true, true).String(), Equals, "0.333334", "n1000000/3 round up"},
+	{divRoundValCheck("0", "3", true, true).IsNative(), Equals, true, "0/3 round up is native"},
+	{ErrorCheck(valueCheck("1").DivRound(*valueCheck("0"), false, true)), ErrorMatches, "Division by zero", "1/0 round up"},
+
+	{mulRoundValCheck("1.000000000000001", "1.000000000000001", false, false).String(), Equals, "1.000000000000002", "Square round down"},
+	{mulRoundValCheck("1.000000000000001", "1.000000000000001", false, true).String(), Equals, "1.000000000000003", "Square round up"},
+	{mulRoundValCheck("-1.000000000000001", "1.000000000000001", false, false).String(), Equals, "-1.000000000000003", "Negative square round down"},
+	{mulRoundValCheck("-1.000000000000001", "1.000000000000001", false, true).String(), Equals, "-1.000000000000002", "Negative square round up"},
+	{mulRoundValCheck("1e-81", "1e-81", false, false).String(), Equals, "0", "1e-81*1e-81 round down"},
+	{mulRoundValCheck("1e-81", "1e-81", false, true).String(), Equals, "1e-81", "1e-81*1e-81 round up"},
+	{mulRoundValCheck("n2", "n3", true, true).String(), Equals, "0.000006", "n2*n3 round up"},
+	{mulRoundValCheck("3", "0.3333333333333333", true, false).String(), Equals, "0", "3*0.3333333333333333 drips round down"},
+	{mulRoundValCheck("3", "0.3333333333333333", true, true).String(), Equals, "0.000001", "3*0.3333333333333333 drips round up"},
+	// rippled only rounds native values up for the last two digits it drops
+	{mulRoundValCheck("1", "1.000000000000001", true, true).String(), Equals, "0.000001", "1*1.000000000000001 drips round up"},
+
 	{ratioValCheck("n1.", "n2.").String(), Equals, "0.5", "n1./n2. ratio"},
 	{ratioValCheck("n-1.", "n2.").String(), Equals, "-0.5", "n-1./n2. ratio"},
 	{ratioValCheck("n1.", "n-200.").String(), Equals, "-0.005", "n1./n-200. ratio"},
@@ -267,6 +291,22 @@ func divValCheck(a, b string) *Value {
 	}
 }
 
+func mulRoundValCheck(a, b string, native, roundUp bool) *Value {
+	if product, err := valueCheck(a).MulRound(*valueCheck(b), native, roundUp); err != nil {
+		panic(err)
+	} else {
+		return product
+	}
+}
+
+func divRoundValCheck(a, b string, native, roundUp bool) *Value {
+	if quotient, err := valueCheck(a).DivRound(*valueCheck(b), native, roundUp); err != nil {
+		panic(err)
+	} else {
+		return quotient
+	}
+}
+
 func ratioValCheck(a, b string) *Value {
 	if ratio, err := valueCheck(a).Ratio(*valueCheck(b)); err != nil {
 		panic(err)
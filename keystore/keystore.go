@@ -0,0 +1,332 @@
+// Package keystore provides an encrypted file format for holding named seeds,
+// so that seeds need not be stored or passed between tools in plaintext.
+//
+// The seeds are encrypted as a whole with XChaCha20-Poly1305 using a key
+// derived from a passphrase with scrypt or argon2id. A keystore must be
+// unlocked with its passphrase before its seeds can be read or changed.
+package keystore
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/ffddw/ripple/crypto"
+	"github.com/ffddw/ripple/data"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	Version = 1
+
+	Scrypt   = "scrypt"
+	Argon2id = "argon2id"
+
+	XChaCha20Poly1305 = "xchacha20-poly1305"
+)
+
+// KDF holds the parameters used to derive the encryption key from the
+// passphrase. N, R and P are used by scrypt and Time, Memory (in KiB) and
+// Threads by argon2id.
+type KDF struct {
+	Function string
+	Salt     data.VariableLength
+	N        int    `json:",omitempty"`
+	R        int    `json:",omitempty"`
+	P        int    `json:",omitempty"`
+	Time     uint32 `json:",omitempty"`
+	Memory   uint32 `json:",omitempty"`
+	Threads  uint8  `json:",omitempty"`
+}
+
+// DefaultKDF is used for new keystores and by Rekey
+var DefaultKDF = KDF{Function: Scrypt, N: 1 << 17, R: 8, P: 1}
+
+func (k *KDF) key(passphrase string) ([]byte, error) {
+	switch k.Function {
+	case Scrypt:
+		return scrypt.Key([]byte(passphrase), k.Salt, k.N, k.R, k.P, chacha20poly1305.KeySize)
+	case Argon2id:
+		if k.Time == 0 || k.Memory == 0 || k.Threads == 0 {
+			return nil, fmt.Errorf("Bad argon2id parameters")
+		}
+		return argon2.IDKey([]byte(passphrase), k.Salt, k.Time, k.Memory, k.Threads, chacha20poly1305.KeySize), nil
+	default:
+		return nil, fmt.Errorf("Unknown key derivation function: %s", k.Function)
+	}
+}
+
+// Entry is a named seed
+type Entry struct {
+	Name    string
+	KeyType data.KeyType
+	Seed    data.Seed
+}
+
+func (e *Entry) Key() crypto.Key {
+	return e.Seed.Key(e.KeyType)
+}
+
+// sequence is that of the root key of an ECDSA seed. Ed25519 seeds have a
+// single key.
+func (e *Entry) sequence() *uint32 {
+	if e.KeyType == data.ECDSA {
+		return new(uint32)
+	}
+	return nil
+}
+
+// Account returns the account of the key
+func (e *Entry) Account() data.Account {
+	return e.Seed.AccountId(e.KeyType, e.sequence())
+}
+
+// Signer returns a Signer for the key
+func (e *Entry) Signer() crypto.Signer {
+	return crypto.NewKeySigner(e.Key(), e.sequence())
+}
+
+// Keystore is the encrypted file format. The exported fields are those
+// written to the file.
+type Keystore struct {
+	Version    int
+	KDF        KDF
+	Cipher     string
+	Nonce      data.VariableLength
+	Ciphertext data.VariableLength
+
+	key     []byte
+	entries []Entry
+}
+
+// New returns an unlocked, empty keystore encrypted with the passphrase
+func New(passphrase string) (*Keystore, error) {
+	k := &Keystore{}
+	if err := k.Rekey(passphrase); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Read returns a locked keystore
+func Read(r io.Reader) (*Keystore, error) {
+	var k Keystore
+	if err := json.NewDecoder(r).Decode(&k); err != nil {
+		return nil, err
+	}
+	if k.Version != Version {
+		return nil, fmt.Errorf("Unsupported keystore version: %d", k.Version)
+	}
+	if k.Cipher != XChaCha20Poly1305 {
+		return nil, fmt.Errorf("Unsupported keystore cipher: %s", k.Cipher)
+	}
+	return &k, nil
+}
+
+// Open reads a locked keystore from a file
+func Open(path string) (*Keystore, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
+
+// Write writes the keystore, which may be locked
+func (k *Keystore) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode(k)
+}
+
+// Save writes the keystore to a file readable only by its owner
+func (k *Keystore) Save(path string) error {
+	var buf bytes.Buffer
+	if err := k.Write(&buf); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, buf.Bytes(), 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+func (k *Keystore) Locked() bool {
+	return k.key == nil
+}
+
+// Unlock decrypts the seeds with the passphrase
+func (k *Keystore) Unlock(passphrase string) error {
+	key, err := k.KDF.key(passphrase)
+	if err != nil {
+		return err
+	}
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return err
+	}
+	if len(k.Nonce) != aead.NonceSize() {
+		return fmt.Errorf("Bad keystore nonce length: %d", len(k.Nonce))
+	}
+	plaintext, err := aead.Open(nil, k.Nonce, k.Ciphertext, k.additionalData())
+	if err != nil {
+		return fmt.Errorf("Bad keystore passphrase")
+	}
+	defer wipe(plaintext)
+	var entries []Entry
+	if err := json.Unmarshal(plaintext, &entries); err != nil {
+		return fmt.Errorf("Bad keystore contents: %s", err)
+	}
+	k.key, k.entries = key, entries
+	return nil
+}
+
+// Lock forgets the encryption key and the decrypted seeds
+func (k *Keystore) Lock() {
+	wipe(k.key)
+	for i := range k.entries {
+		wipe(k.entries[i].Seed[:])
+	}
+	k.key, k.entries = nil, nil
+}
+
+// Rekey re-encrypts an unlocked or new keystore with a new passphrase, salt
+// and the current DefaultKDF
+func (k *Keystore) Rekey(passphrase string) error {
+	if k.Version != 0 && k.Locked() {
+		return errLocked
+	}
+	kdf := DefaultKDF
+	kdf.Salt = make([]byte, 32)
+	if _, err := rand.Read(kdf.Salt); err != nil {
+		return err
+	}
+	key, err := kdf.key(passphrase)
+	if err != nil {
+		return err
+	}
+	k.Version, k.Cipher, k.KDF = Version, XChaCha20Poly1305, kdf
+	wipe(k.key)
+	k.key = key
+	return k.seal()
+}
+
+// additionalData binds the cipher text to the KDF parameters
+func (k *Keystore) additionalData() []byte {
+	b, _ := json.Marshal(k.KDF)
+	return b
+}
+
+func (k *Keystore) seal() error {
+	aead, err := chacha20poly1305.NewX(k.key)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(k.entries)
+	if err != nil {
+		return err
+	}
+	defer wipe(plaintext)
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	k.Nonce, k.Ciphertext = nonce, aead.Seal(nil, nonce, plaintext, k.additionalData())
+	return nil
+}
+
+var errLocked = fmt.Errorf("Keystore is locked")
+
+func (k *Keystore) find(name string) (int, error) {
+	if k.Locked() {
+		return -1, errLocked
+	}
+	for i := range k.entries {
+		if k.entries[i].Name == name {
+			return i, nil
+		}
+	}
+	return -1, nil
+}
+
+// Names returns the sorted names of the seeds in an unlocked keystore
+func (k *Keystore) Names() ([]string, error) {
+	if k.Locked() {
+		return nil, errLocked
+	}
+	names := make([]string, len(k.entries))
+	for i := range k.entries {
+		names[i] = k.entries[i].Name
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Get returns a copy of the named entry
+func (k *Keystore) Get(name string) (*Entry, error) {
+	i, err := k.find(name)
+	switch {
+	case err != nil:
+		return nil, err
+	case i < 0:
+		return nil, fmt.Errorf("Unknown key: %s", name)
+	}
+	entry := k.entries[i]
+	return &entry, nil
+}
+
+// Import adds a named seed
+func (k *Keystore) Import(name string, seed data.Seed, keyType data.KeyType) error {
+	if name == "" {
+		return fmt.Errorf("Key name is empty")
+	}
+	i, err := k.find(name)
+	switch {
+	case err != nil:
+		return err
+	case i >= 0:
+		return fmt.Errorf("Duplicate key: %s", name)
+	}
+	k.entries = append(k.entries, Entry{Name: name, KeyType: keyType, Seed: seed})
+	return k.seal()
+}
+
+// Export returns the named seed and its key type
+func (k *Keystore) Export(name string) (*data.Seed, data.KeyType, error) {
+	entry, err := k.Get(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	return &entry.Seed, entry.KeyType, nil
+}
+
+// Remove deletes the named seed
+func (k *Keystore) Remove(name string) error {
+	i, err := k.find(name)
+	switch {
+	case err != nil:
+		return err
+	case i < 0:
+		return fmt.Errorf("Unknown key: %s", name)
+	}
+	last := len(k.entries) - 1
+	wipe(k.entries[i].Seed[:])
+	copy(k.entries[i:], k.entries[i+1:])
+	wipe(k.entries[last].Seed[:])
+	k.entries = k.entries[:last]
+	return k.seal()
+}
+
+func wipe(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
@@ -0,0 +1,135 @@
+package keystore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ffddw/ripple/data"
+)
+
+func init() {
+	DefaultKDF = KDF{Function: Scrypt, N: 1 << 10, R: 8, P: 1}
+}
+
+func testSeed(t *testing.T) data.Seed {
+	seed, err := data.NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *seed
+}
+
+func reopen(t *testing.T, k *Keystore) *Keystore {
+	var buf bytes.Buffer
+	if err := k.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	k, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestKeystore(t *testing.T) {
+	k, err := New("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seed := testSeed(t)
+	if err := k.Import("master", seed, data.ECDSA); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Import("ed", seed, data.Ed25519); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Import("master", seed, data.Ed25519); err == nil || err.Error() != "Duplicate key: master" {
+		t.Fatalf("Expected duplicate key error, got: %v", err)
+	}
+
+	k = reopen(t, k)
+	if _, err := k.Names(); err != errLocked {
+		t.Fatalf("Expected locked error, got: %v", err)
+	}
+	if err := k.Unlock("wrong"); err == nil || err.Error() != "Bad keystore passphrase" {
+		t.Fatalf("Expected bad passphrase error, got: %v", err)
+	}
+	if err := k.Unlock("passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	names, err := k.Names()
+	if err != nil || len(names) != 2 || names[0] != "ed" || names[1] != "master" {
+		t.Fatalf("Bad names: %v %v", names, err)
+	}
+	entry, err := k.Get("master")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if account := entry.Account().String(); account != "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh" {
+		t.Fatalf("Bad account: %s", account)
+	}
+	exported, keyType, err := k.Export("ed")
+	if err != nil || *exported != seed || keyType != data.Ed25519 {
+		t.Fatalf("Bad export: %s %s %v", exported, keyType, err)
+	}
+
+	if err := k.Remove("ed"); err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Rekey("new passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	k.Lock()
+	if _, err := k.Get("master"); err != errLocked {
+		t.Fatalf("Expected locked error, got: %v", err)
+	}
+	if err := k.Rekey("other"); err != errLocked {
+		t.Fatalf("Expected locked error, got: %v", err)
+	}
+	k = reopen(t, k)
+	if err := k.Unlock("passphrase"); err == nil {
+		t.Fatal("Old passphrase unlocked rekeyed keystore")
+	}
+	if err := k.Unlock("new passphrase"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := k.Get("ed"); err == nil {
+		t.Fatal("Removed key is still present")
+	}
+}
+
+func TestKeystoreTampering(t *testing.T) {
+	k, err := New("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Import("master", testSeed(t), data.ECDSA); err != nil {
+		t.Fatal(err)
+	}
+	k = reopen(t, k)
+	k.KDF.N = 1 << 11
+	if err := k.Unlock("passphrase"); err == nil {
+		t.Fatal("Changed KDF parameters were not detected")
+	}
+	k.KDF.N = 1 << 10
+	k.Ciphertext[0] ^= 1
+	if err := k.Unlock("passphrase"); err == nil {
+		t.Fatal("Changed cipher text was not detected")
+	}
+}
+
+func TestArgon2id(t *testing.T) {
+	defer func(kdf KDF) { DefaultKDF = kdf }(DefaultKDF)
+	DefaultKDF = KDF{Function: Argon2id, Time: 1, Memory: 64, Threads: 1}
+	k, err := New("passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := k.Import("master", testSeed(t), data.ECDSA); err != nil {
+		t.Fatal(err)
+	}
+	k = reopen(t, k)
+	if err := k.Unlock("passphrase"); err != nil {
+		t.Fatal(err)
+	}
+}
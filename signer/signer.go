@@ -0,0 +1,183 @@
+// Package signer lets a separate process hold signing keys and sign for
+// clients over a Unix socket, so keys need not be loaded by the processes
+// that build and submit transactions.
+//
+// The protocol is one JSON request per line, answered by one JSON response
+// per line. A client asks for the public key of a named key, or for a
+// signature of a hash and message by a named key.
+package signer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"sync"
+
+	"github.com/ffddw/ripple/crypto"
+	"github.com/ffddw/ripple/data"
+)
+
+const (
+	PublicKey = "PublicKey"
+	Sign      = "Sign"
+)
+
+// Request is sent by the client
+type Request struct {
+	Method  string
+	Key     string
+	Hash    data.VariableLength `json:",omitempty"`
+	Message data.VariableLength `json:",omitempty"`
+}
+
+// Response is returned by the server
+type Response struct {
+	PublicKey data.VariableLength `json:",omitempty"`
+	Signature data.VariableLength `json:",omitempty"`
+	Error     string              `json:",omitempty"`
+}
+
+// Server signs with named Signers for clients
+type Server struct {
+	signers map[string]crypto.Signer
+}
+
+func NewServer(signers map[string]crypto.Signer) *Server {
+	return &Server{signers: signers}
+}
+
+// Serve handles connections from the listener until it is closed
+func (s *Server) Serve(l net.Listener) error {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// ServeConn handles the requests from a single connection
+func (s *Server) ServeConn(conn io.ReadWriteCloser) {
+	defer conn.Close()
+	dec, enc := json.NewDecoder(bufio.NewReader(conn)), json.NewEncoder(conn)
+	for {
+		var request Request
+		if err := dec.Decode(&request); err != nil {
+			if err != io.EOF {
+				log.Printf("Signer: bad request: %s", err)
+			}
+			return
+		}
+		response := s.handle(&request)
+		if err := enc.Encode(response); err != nil {
+			log.Printf("Signer: %s", err)
+			return
+		}
+	}
+}
+
+func (s *Server) handle(request *Request) *Response {
+	var response Response
+	signer, ok := s.signers[request.Key]
+	if !ok {
+		response.Error = fmt.Sprintf("Unknown key: %s", request.Key)
+		return &response
+	}
+	var err error
+	switch request.Method {
+	case PublicKey:
+		response.PublicKey, err = signer.PublicKey()
+	case Sign:
+		response.Signature, err = signer.Sign(request.Hash, request.Message)
+	default:
+		err = fmt.Errorf("Unknown method: %s", request.Method)
+	}
+	if err != nil {
+		response.Error = err.Error()
+	}
+	return &response
+}
+
+// Client is a connection to a Server which may be shared by goroutines
+type Client struct {
+	mu   sync.Mutex
+	conn io.ReadWriteCloser
+	dec  *json.Decoder
+	enc  *json.Encoder
+}
+
+// Dial connects to a Server listening on a Unix socket
+func Dial(path string) (*Client, error) {
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(conn), nil
+}
+
+func NewClient(conn io.ReadWriteCloser) *Client {
+	return &Client{
+		conn: conn,
+		dec:  json.NewDecoder(bufio.NewReader(conn)),
+		enc:  json.NewEncoder(conn),
+	}
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
+
+func (c *Client) call(request *Request) (*Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.enc.Encode(request); err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := c.dec.Decode(&response); err != nil {
+		return nil, err
+	}
+	if response.Error != "" {
+		return nil, fmt.Errorf("Signer: %s", response.Error)
+	}
+	return &response, nil
+}
+
+// Signer returns a Signer for the named key. The public key is fetched once
+// and then cached.
+func (c *Client) Signer(key string) crypto.Signer {
+	return &remoteSigner{client: c, key: key}
+}
+
+type remoteSigner struct {
+	client *Client
+	key    string
+	mu     sync.Mutex
+	public []byte
+}
+
+func (s *remoteSigner) PublicKey() ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.public != nil {
+		return s.public, nil
+	}
+	response, err := s.client.call(&Request{Method: PublicKey, Key: s.key})
+	if err != nil {
+		return nil, err
+	}
+	s.public = response.PublicKey
+	return s.public, nil
+}
+
+func (s *remoteSigner) Sign(hash, msg []byte) ([]byte, error) {
+	response, err := s.client.call(&Request{Method: Sign, Key: s.key, Hash: hash, Message: msg})
+	if err != nil {
+		return nil, err
+	}
+	return response.Signature, nil
+}
@@ -0,0 +1,102 @@
+package signer
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/ffddw/ripple/crypto"
+	"github.com/ffddw/ripple/data"
+)
+
+// newTestClient serves an ECDSA and an ed25519 key from seed
+func newTestClient(t *testing.T, seed *data.Seed, sequence *uint32) *Client {
+	t.Helper()
+	server := NewServer(map[string]crypto.Signer{
+		"ecdsa":   crypto.NewKeySigner(seed.Key(data.ECDSA), sequence),
+		"ed25519": crypto.NewKeySigner(seed.Key(data.Ed25519), nil),
+	})
+	path := filepath.Join(t.TempDir(), "signer.sock")
+	l, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+	go server.Serve(l)
+
+	client, err := Dial(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+func TestRemoteSigning(t *testing.T) {
+	seed, err := data.NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var sequence uint32
+	client := newTestClient(t, seed, &sequence)
+	amount, err := data.NewAmount("1000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, test := range []struct {
+		Key     string
+		Account data.Account
+	}{
+		{"ecdsa", seed.AccountId(data.ECDSA, &sequence)},
+		{"ed25519", seed.AccountId(data.Ed25519, nil)},
+	} {
+		payment := &data.Payment{
+			TxBase: data.TxBase{TransactionType: data.PAYMENT, Account: test.Account},
+			Amount: *amount,
+		}
+		if err := data.SignWith(payment, client.Signer(test.Key)); err != nil {
+			t.Fatalf("%s: %s", test.Key, err)
+		}
+		if ok, err := data.CheckSignature(payment); !ok || err != nil {
+			t.Fatalf("%s: bad signature: %v", test.Key, err)
+		}
+	}
+	if _, err := client.Signer("missing").PublicKey(); err == nil || err.Error() != "Signer: Unknown key: missing" {
+		t.Fatalf("Expected unknown key error, got: %v", err)
+	}
+}
+
+func TestConcurrentPublicKey(t *testing.T) {
+	seed, err := data.NewSeedFromAddress("snoPBrXtMeMyMHUVTgbuqAfg1SUTb")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := crypto.NewKeySigner(seed.Key(data.Ed25519), nil).PublicKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer := newTestClient(t, seed, nil).Signer("ed25519")
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			public, err := signer.PublicKey()
+			if err == nil && !bytes.Equal(public, expected) {
+				err = fmt.Errorf("Expected %X got %X", expected, public)
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
@@ -214,8 +214,8 @@ func newBundle(value interface{}, flag Flag) (*bundle, error) {
 	case data.Trade:
 		return &bundle{
 			color:  tradeStyle,
-			format: "Trade: %-34s => %-34s  %22.8f  %60s =>  %-60s",
-			values: []interface{}{v.Giver, v.Taker, v.Rate(), v.Got, v.Paid},
+			format: "Trade: %-34s => %-34s  %22.8f  %60s =>  %-60s %s",
+			values: []interface{}{v.Giver, v.Taker, v.Rate(), v.Got, v.Paid, v.Liquidity()},
 			flag:   flag,
 		}, nil
 	case data.Balance:
@@ -225,6 +225,20 @@ func newBundle(value interface{}, flag Flag) (*bundle, error) {
 			values: []interface{}{v.CounterParty, v.Currency, v.Balance, v.Change},
 			flag:   flag,
 		}, nil
+	case data.BalanceChange:
+		return &bundle{
+			color:  balanceStyle,
+			format: "%s",
+			values: []interface{}{v.String()},
+			flag:   flag,
+		}, nil
+	case data.Delivery:
+		return &bundle{
+			color:  balanceStyle,
+			format: "Delivered: %-34s  %s of %s Partial: %s Derived: %s",
+			values: []interface{}{v.Destination, v.Delivered, v.Requested, BoolSymbol(v.Partial), BoolSymbol(v.Derived)},
+			flag:   flag,
+		}, nil
 	case data.Path:
 		sig, err := v.Signature()
 		if err != nil {
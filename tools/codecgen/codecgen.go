@@ -0,0 +1,520 @@
+// Tool to generate reflection free binary encoders and decoders for the
+// transactions, ledger entries and metadata of the data package. Types with
+// fields which cannot be generated are left to the reflective codec.
+//
+// Run via go generate in the data directory. The tool imports the data
+// package, so a broken codec_generated.go must be deleted before rerunning.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ffddw/ripple/data"
+)
+
+var output = flag.String("o", "codec_generated.go", "output file")
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+var (
+	wireType        = reflect.TypeOf((*data.Wire)(nil)).Elem()
+	ledgerEntryType = reflect.TypeOf((*data.LedgerEntry)(nil)).Elem()
+	unknownType     = reflect.TypeOf(data.UnknownFields(nil))
+)
+
+type kind int
+
+const (
+	kindWire kind = iota
+	kindUint
+	kindObject
+	kindLedgerEntry
+	kindArray
+)
+
+type field struct {
+	Name      string
+	Selector  string
+	Depth     int
+	Type      reflect.Type // with any pointer removed
+	Pointer   bool
+	Kind      kind
+	Typ, Code uint8
+	OmitInner bool // LedgerEntryType of ledger entries held in metadata
+}
+
+func (f *field) priority() uint32 { return uint32(f.Typ)<<16 | uint32(f.Code) }
+func (f *field) enc() string      { return fmt.Sprintf("enc{%d, %d}", f.Typ, f.Code) }
+func (f *field) signing() bool {
+	return strings.Contains(f.Name, "Signature") || f.Name == "Signers"
+}
+
+// uintType returns the unsigned integer type with the size of f
+func (f *field) uintType() string {
+	return fmt.Sprintf("uint%d", f.Type.Size()*8)
+}
+
+func (f *field) readFunc() string {
+	return fmt.Sprintf("readUint%d", f.Type.Size()*8)
+}
+
+func (f *field) writeFunc() string {
+	return fmt.Sprintf("writeUint%d", f.Type.Size()*8)
+}
+
+type object struct {
+	Type    reflect.Type
+	Fields  []field // sorted into encoding order
+	Unknown string  // selector of the UnknownFields, if any
+	err     error
+}
+
+// decodeFields returns the field to decode into for each encoding. As with
+// reflect.Value.FieldByName, the shallowest of duplicate fields wins.
+func (o *object) decodeFields() []*field {
+	seen := make(map[uint32]int)
+	var fields []*field
+	for i := range o.Fields {
+		f := &o.Fields[i]
+		if j, ok := seen[f.priority()]; ok {
+			if f.Depth < fields[j].Depth {
+				fields[j] = f
+			}
+			continue
+		}
+		seen[f.priority()] = len(fields)
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+func (o *object) field(name string) *field {
+	for _, f := range o.decodeFields() {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// wrapper returns true if o is an array element which holds its object in
+// a field, such as Memo, rather than being the object itself, such as Majority
+func (o *object) wrapper() bool {
+	name := o.Type.Name()
+	if _, ok := o.Type.FieldByName(name); ok {
+		return true
+	}
+	typ, _, ok := data.FieldCode(name)
+	return !ok || typ != data.ST_OBJECT
+}
+
+type generator struct {
+	buf      bytes.Buffer
+	cache    map[reflect.Type]*object
+	objects  []*object
+	elements []*object
+	added    map[reflect.Type]bool
+	headers  map[string]string
+	skipped  []string
+}
+
+func newGenerator() *generator {
+	return &generator{
+		cache: make(map[reflect.Type]*object),
+		added: make(map[reflect.Type]bool),
+		headers: map[string]string{
+			"EndOfObject": "enc{14, 1}",
+			"EndOfArray":  "enc{15, 1}",
+		},
+	}
+}
+
+func (g *generator) object(t reflect.Type) (*object, error) {
+	if o, ok := g.cache[t]; ok {
+		return o, o.err
+	}
+	o := &object{Type: t}
+	g.cache[t] = o
+	o.err = g.analyse(o, t, "", 0)
+	sort.SliceStable(o.Fields, func(i, j int) bool {
+		return o.Fields[i].priority() < o.Fields[j].priority()
+	})
+	if o.err == nil {
+		o.err = g.check(o)
+	}
+	return o, o.err
+}
+
+func (g *generator) analyse(o *object, t reflect.Type, prefix string, depth int) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		selector := prefix + sf.Name
+		switch {
+		case sf.Name == "Hash" || sf.Name == "Id":
+			continue
+		case sf.Type == unknownType:
+			if o.Unknown == "" {
+				o.Unknown = selector
+			}
+			continue
+		case sf.Anonymous && sf.Type.Kind() == reflect.Struct:
+			if err := g.analyse(o, sf.Type, selector+".", depth+1); err != nil {
+				return err
+			}
+			continue
+		}
+		f, err := g.classify(sf, selector, depth)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %s", o.Type.Name(), sf.Name, err)
+		}
+		f.OmitInner = sf.Name == "LedgerEntryType" && t.Name() == "leBase"
+		o.Fields = append(o.Fields, *f)
+	}
+	return nil
+}
+
+func (g *generator) classify(sf reflect.StructField, selector string, depth int) (*field, error) {
+	typ, code, ok := data.FieldCode(sf.Name)
+	if !ok {
+		return nil, fmt.Errorf("no encoding")
+	}
+	f := &field{
+		Name:     sf.Name,
+		Selector: selector,
+		Depth:    depth,
+		Type:     sf.Type,
+		Typ:      typ,
+		Code:     code,
+	}
+	if f.Type.Kind() == reflect.Ptr {
+		f.Pointer, f.Type = true, f.Type.Elem()
+	}
+	switch {
+	case f.Type == ledgerEntryType && typ == data.ST_OBJECT:
+		f.Kind = kindLedgerEntry
+	case reflect.PtrTo(f.Type).Implements(wireType):
+		f.Kind = kindWire
+	case typ == data.ST_UINT8 || typ == data.ST_UINT16 || typ == data.ST_UINT32 || typ == data.ST_UINT64:
+		switch f.Type.Kind() {
+		case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			f.Kind = kindUint
+		default:
+			return nil, fmt.Errorf("unsupported integer type %s", f.Type)
+		}
+	case typ == data.ST_OBJECT && f.Type.Kind() == reflect.Struct:
+		if _, err := g.object(f.Type); err != nil {
+			return nil, err
+		}
+		f.Kind = kindObject
+	case typ == data.ST_ARRAY && f.Type.Kind() == reflect.Slice && f.Type.Elem().Kind() == reflect.Struct && !f.Pointer:
+		if _, err := g.element(f.Type.Elem()); err != nil {
+			return nil, err
+		}
+		f.Kind = kindArray
+	default:
+		return nil, fmt.Errorf("unsupported type %s", sf.Type)
+	}
+	return f, nil
+}
+
+// check ensures that the fields an object refers to exist
+func (g *generator) check(o *object) error {
+	for _, f := range o.Fields {
+		if f.Kind != kindLedgerEntry {
+			continue
+		}
+		if leType := o.field("LedgerEntryType"); leType == nil || leType.Kind != kindUint {
+			return fmt.Errorf("%s.%s: no LedgerEntryType", o.Type.Name(), f.Name)
+		}
+	}
+	return nil
+}
+
+func (g *generator) element(t reflect.Type) (*object, error) {
+	o, err := g.object(t)
+	if err != nil {
+		return nil, err
+	}
+	if o.wrapper() {
+		for _, f := range o.Fields {
+			if f.Kind != kindObject {
+				return nil, fmt.Errorf("%s.%s: wrapped element is not an object", t.Name(), f.Name)
+			}
+		}
+	}
+	return o, nil
+}
+
+// add registers t, and every type it contains, for generation
+func (g *generator) add(t reflect.Type) {
+	o, err := g.object(t)
+	if err != nil {
+		log.Printf("Using reflective codec for %s: %s", t.Name(), err)
+		g.skipped = append(g.skipped, t.Name())
+		return
+	}
+	g.addObject(o)
+}
+
+func (g *generator) addObject(o *object) {
+	if g.added[o.Type] {
+		return
+	}
+	g.added[o.Type] = true
+	g.objects = append(g.objects, o)
+	g.addFields(o)
+}
+
+func (g *generator) addFields(o *object) {
+	for _, f := range o.Fields {
+		g.headers[f.Name] = f.enc()
+		switch f.Kind {
+		case kindObject:
+			g.addObject(g.cache[f.Type])
+		case kindArray:
+			g.addElement(g.cache[f.Type.Elem()])
+		}
+	}
+}
+
+func (g *generator) addElement(o *object) {
+	for _, e := range g.elements {
+		if e == o {
+			return
+		}
+	}
+	g.elements = append(g.elements, o)
+	if o.wrapper() {
+		g.addFields(o)
+		return
+	}
+	typ, code, _ := data.FieldCode(o.Type.Name())
+	g.headers[o.Type.Name()] = fmt.Sprintf("enc{%d, %d}", typ, code)
+	g.addObject(o)
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) generate() ([]byte, error) {
+	g.printf("// Code generated by codecgen. DO NOT EDIT.\n\n")
+	g.printf("package data\n\n")
+	g.printf("import (\n\"fmt\"\n\"io\"\n)\n\n")
+	if len(g.skipped) > 0 {
+		g.printf("// Types using the reflective codec: %s\n\n", strings.Join(g.skipped, ", "))
+	}
+	var names []string
+	for name := range g.headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	g.printf("var (\n")
+	for _, name := range names {
+		g.printf("hdr%s = %s.header()\n", name, g.headers[name])
+	}
+	g.printf(")\n\n")
+	for _, o := range g.objects {
+		g.marshal(o)
+		g.unmarshal(o)
+	}
+	for _, o := range g.elements {
+		g.marshalElement(o)
+		g.unmarshalElement(o)
+		g.unmarshalArray(o)
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+func (g *generator) writeHeader(name string) {
+	g.printf("if _, err := w.Write(hdr%s); err != nil {\nreturn err\n}\n", name)
+}
+
+func (g *generator) marshal(o *object) {
+	g.printf("func (x *%s) marshal(w io.Writer, ignoreSigningFields, inner bool) error {\n", o.Type.Name())
+	if o.Unknown != "" {
+		g.printf("if len(x.%s) > 0 {\nreturn encodeReflect(w, x, ignoreSigningFields, inner)\n}\n", o.Unknown)
+	}
+	for i := range o.Fields {
+		g.marshalField(&o.Fields[i])
+	}
+	g.printf("return nil\n}\n\n")
+}
+
+func (g *generator) marshalField(f *field) {
+	var conditions []string
+	if f.signing() {
+		conditions = append(conditions, "!ignoreSigningFields")
+	}
+	if f.OmitInner {
+		conditions = append(conditions, "!inner")
+	}
+	if f.Pointer || f.Kind == kindLedgerEntry {
+		conditions = append(conditions, fmt.Sprintf("x.%s != nil", f.Selector))
+	}
+	if f.Type.Kind() == reflect.Slice {
+		if f.Pointer {
+			conditions = append(conditions, fmt.Sprintf("len(*x.%s) > 0", f.Selector))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("len(x.%s) > 0", f.Selector))
+		}
+	}
+	if len(conditions) > 0 {
+		g.printf("if %s {\n", strings.Join(conditions, " && "))
+	}
+	g.writeHeader(f.Name)
+	switch f.Kind {
+	case kindWire:
+		g.printf("if err := x.%s.Marshal(w); err != nil {\nreturn err\n}\n", f.Selector)
+	case kindUint:
+		value := "x." + f.Selector
+		if f.Pointer {
+			value = "*" + value
+		}
+		g.printf("if err := %s(w, %s(%s)); err != nil {\nreturn err\n}\n", f.writeFunc(), f.uintType(), value)
+	case kindObject:
+		g.printf("if err := x.%s.marshal(w, ignoreSigningFields, true); err != nil {\nreturn err\n}\n", f.Selector)
+		g.writeHeader("EndOfObject")
+	case kindLedgerEntry:
+		g.printf("if err := encodeInner(w, x.%s, ignoreSigningFields); err != nil {\nreturn err\n}\n", f.Selector)
+		g.writeHeader("EndOfObject")
+	case kindArray:
+		g.printf("for i := range x.%s {\n", f.Selector)
+		g.printf("if err := x.%s[i].marshalElement(w, ignoreSigningFields); err != nil {\nreturn err\n}\n", f.Selector)
+		g.printf("}\n")
+		g.writeHeader("EndOfArray")
+	}
+	if len(conditions) > 0 {
+		g.printf("}\n")
+	}
+}
+
+func (g *generator) unmarshal(o *object) {
+	g.printf("func (x *%s) unmarshal(r Reader, s *decodeState) error {\n", o.Type.Name())
+	g.printf("for {\noffset := s.offset()\ne, err := readFieldHeader(r)\nif err != nil {\nreturn nil\n}\n")
+	g.printf("switch e {\n")
+	for _, f := range o.decodeFields() {
+		g.printf("case %s:\n", f.enc())
+		g.unmarshalField(o, f)
+	}
+	g.printf("case enc{14, 1}:\nreturn errorEndOfObject\n")
+	g.printf("case enc{15, 1}:\nreturn errorEndOfArray\n")
+	g.printf("default:\n")
+	if o.Unknown != "" {
+		g.printf("if x.%s == nil {\nx.%s = make(UnknownFields)\n}\n", o.Unknown, o.Unknown)
+		g.printf("if err := x.%s.read(r, e); err != nil {\nreturn s.fieldError(offset, e, err)\n}\n", o.Unknown)
+	} else {
+		g.printf("return s.fieldError(offset, e, fmt.Errorf(\"Missing field: %%s %%+v\", encodings[e], e))\n")
+	}
+	g.printf("}\n}\n}\n\n")
+}
+
+func (g *generator) unmarshalField(o *object, f *field) {
+	switch f.Kind {
+	case kindWire:
+		if f.Pointer {
+			g.printf("x.%s = new(%s)\n", f.Selector, f.Type.Name())
+		}
+		g.printf("if err := x.%s.Unmarshal(r); err != nil {\nreturn s.fieldError(offset, e, err)\n}\n", f.Selector)
+	case kindUint:
+		g.printf("v, err := %s(r)\nif err != nil {\nreturn s.fieldError(offset, e, err)\n}\n", f.readFunc())
+		if f.Pointer {
+			g.printf("x.%s = new(%s)\n*x.%s = %s(v)\n", f.Selector, f.Type.Name(), f.Selector, f.Type.Name())
+		} else {
+			g.printf("x.%s = %s(v)\n", f.Selector, f.Type.Name())
+		}
+	case kindObject:
+		if f.Pointer {
+			g.printf("x.%s = new(%s)\n", f.Selector, f.Type.Name())
+		}
+		g.printf("s.push(%q)\nerr := x.%s.unmarshal(r, s)\ns.pop()\n", f.Name, f.Selector)
+		g.printf("if err != errorEndOfObject {\nreturn s.fieldError(offset, e, err)\n}\n")
+	case kindLedgerEntry:
+		g.printf("le, err := newLedgerEntry(x.%s)\nif err != nil {\nreturn s.fieldError(offset, e, err)\n}\n", o.field("LedgerEntryType").Selector)
+		g.printf("x.%s = le\n", f.Selector)
+		g.printf("s.push(%q)\nerr = decodeObject(r, le, s)\ns.pop()\n", f.Name)
+		g.printf("if err != nil && err != errorEndOfObject {\nreturn s.fieldError(offset, e, err)\n}\n")
+	case kindArray:
+		g.printf("s.push(%q)\narray, more, err := unmarshal%sArray(r, s)\ns.pop()\n", f.Name, f.Type.Elem().Name())
+		g.printf("x.%s = array\n", f.Selector)
+		g.printf("if !more {\nreturn s.fieldError(offset, e, err)\n}\n")
+	}
+}
+
+func (g *generator) marshalElement(o *object) {
+	name := o.Type.Name()
+	g.printf("func (x *%s) marshalElement(w io.Writer, ignoreSigningFields bool) error {\n", name)
+	if o.wrapper() {
+		for i := range o.Fields {
+			g.marshalField(&o.Fields[i])
+		}
+		g.printf("return nil\n}\n\n")
+		return
+	}
+	g.writeHeader(name)
+	g.printf("if err := x.marshal(w, ignoreSigningFields, true); err != nil {\nreturn err\n}\n")
+	g.printf("_, err := w.Write(hdrEndOfObject)\nreturn err\n}\n\n")
+}
+
+func (g *generator) unmarshalElement(o *object) {
+	name := o.Type.Name()
+	g.printf("func (x *%s) unmarshalElement(r Reader, offset int, e enc, s *decodeState) error {\n", name)
+	g.printf("switch e {\n")
+	if o.wrapper() {
+		for _, f := range o.decodeFields() {
+			g.printf("case %s:\n", f.enc())
+			if f.Pointer {
+				g.printf("x.%s = new(%s)\n", f.Selector, f.Type.Name())
+			}
+			g.printf("s.push(%q)\ndefer s.pop()\nreturn x.%s.unmarshal(r, s)\n", f.Name, f.Selector)
+		}
+	} else {
+		g.printf("case %s:\n", g.headers[name])
+		g.printf("s.push(%q)\ndefer s.pop()\nreturn x.unmarshal(r, s)\n", name)
+	}
+	g.printf("case enc{14, 1}:\nreturn errorEndOfObject\n")
+	g.printf("default:\nreturn s.fieldError(offset, e, fmt.Errorf(\"Unexpected object: %%s in %s\", encodings[e]))\n", name)
+	g.printf("}\n}\n\n")
+}
+
+func (g *generator) unmarshalArray(o *object) {
+	name := o.Type.Name()
+	g.printf("func unmarshal%sArray(r Reader, s *decodeState) ([]%s, bool, error) {\n", name, name)
+	g.printf("var array []%s\n", name)
+	g.printf("for i := 0; ; i++ {\noffset := s.offset()\ne, err := readFieldHeader(r)\nif err != nil {\nreturn array, false, nil\n}\n")
+	g.printf("if e == (enc{15, 1}) {\nreturn array, true, nil\n}\n")
+	g.printf("var element %s\ns.pushIndex(i)\nerr = element.unmarshalElement(r, offset, e, s)\ns.pop()\n", name)
+	g.printf("if err != errorEndOfObject {\nreturn array, false, err\n}\n")
+	g.printf("array = append(array, element)\n}\n}\n\n")
+}
+
+func main() {
+	flag.Parse()
+	g := newGenerator()
+	for _, factory := range data.TxFactory {
+		if factory != nil {
+			g.add(reflect.TypeOf(factory()).Elem())
+		}
+	}
+	for _, factory := range data.LedgerEntryFactory {
+		if factory != nil {
+			g.add(reflect.TypeOf(factory()).Elem())
+		}
+	}
+	g.add(reflect.TypeOf(data.MetaData{}))
+	src, err := g.generate()
+	checkErr(err)
+	checkErr(os.WriteFile(*output, src, 0644))
+}
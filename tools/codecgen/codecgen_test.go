@@ -0,0 +1,2 @@
+// Empty test file to ensure codecgen tool compiles
+package main
@@ -44,6 +44,7 @@ var (
 	balances     = flag.Bool("b", false, "hide balances")
 	paths        = flag.Bool("p", false, "hide paths")
 	transactions = flag.Bool("tx", false, "hide transactions")
+	deliveries   = flag.Bool("d", false, "hide delivered amounts")
 	pageSize     = flag.Int("page_size", 20, "page size for account_tx requests")
 )
 
@@ -64,6 +65,13 @@ func explain(txm *data.TransactionWithMetaData, flag terminal.Flag) {
 	if !*transactions {
 		terminal.Println(txm, flag)
 	}
+	if !*deliveries {
+		delivery, err := txm.Delivered()
+		checkErr(err)
+		if delivery != nil {
+			terminal.Println(*delivery, flag|terminal.Indent)
+		}
+	}
 	if !*paths {
 		for _, path := range txm.PathSet() {
 			terminal.Println(path, flag|terminal.Indent)
@@ -77,13 +85,10 @@ func explain(txm *data.TransactionWithMetaData, flag terminal.Flag) {
 		}
 	}
 	if !*balances {
-		balanceMap, err := txm.Balances()
+		changes, err := txm.BalanceChanges()
 		checkErr(err)
-		for account, balances := range balanceMap {
-			terminal.Println(account, flag|terminal.Indent)
-			for _, balance := range *balances {
-				terminal.Println(balance, flag|terminal.DoubleIndent)
-			}
+		for _, change := range changes {
+			terminal.Println(change, flag|terminal.Indent)
 		}
 	}
 }
@@ -131,6 +136,9 @@ func main() {
 			checkErr(err)
 			var nodeid data.Hash256
 			v, err := data.ReadPrefix(bytes.NewReader(b), nodeid)
+			if err != nil {
+				terminal.Println(data.HexDump(b, err), terminal.Default)
+			}
 			checkErr(err)
 			terminal.Println(v, terminal.Default)
 		}
@@ -0,0 +1,112 @@
+// Tool to manage an encrypted keystore of named seeds, whose keys can then be
+// referred to by name in the actions read by the submit tool
+//
+// Usage:
+//
+//	keystore -file keys.json create
+//	keystore -file keys.json import name seed
+//	keystore -file keys.json list
+//	keystore -file keys.json export name
+//	keystore -file keys.json remove name
+//	keystore -file keys.json rekey
+//
+// The passphrase is read from the environment variable named by -passphrase,
+// and the new passphrase for rekey from the one named by -new-passphrase.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ffddw/ripple/data"
+	"github.com/ffddw/ripple/keystore"
+)
+
+var (
+	file          = flag.String("file", "keystore.json", "keystore file")
+	passphrase    = flag.String("passphrase", "RIPPLE_KEYSTORE_PASSPHRASE", "environment variable holding the keystore passphrase")
+	newPassphrase = flag.String("new-passphrase", "RIPPLE_KEYSTORE_NEW_PASSPHRASE", "environment variable holding the new passphrase for rekey")
+	ed25519       = flag.Bool("ed25519", false, "import an ed25519 seed")
+)
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func getPassphrase(name string) string {
+	value := os.Getenv(name)
+	if value == "" {
+		log.Fatalf("Environment variable %s is not set", name)
+	}
+	return value
+}
+
+func args(n int) []string {
+	if flag.NArg() != n+1 {
+		log.Fatalf("Usage: keystore [flags] %s: wrong number of arguments", flag.Arg(0))
+	}
+	return flag.Args()[1:]
+}
+
+func open() *keystore.Keystore {
+	ks, err := keystore.Open(*file)
+	checkErr(err)
+	checkErr(ks.Unlock(getPassphrase(*passphrase)))
+	return ks
+}
+
+func main() {
+	flag.Parse()
+	switch flag.Arg(0) {
+	case "create":
+		args(0)
+		if _, err := os.Stat(*file); err == nil {
+			log.Fatalf("Keystore %s already exists", *file)
+		}
+		ks, err := keystore.New(getPassphrase(*passphrase))
+		checkErr(err)
+		checkErr(ks.Save(*file))
+	case "import":
+		a := args(2)
+		seed, err := data.NewSeedFromAddress(a[1])
+		checkErr(err)
+		keyType := data.ECDSA
+		if *ed25519 {
+			keyType = data.Ed25519
+		}
+		ks := open()
+		checkErr(ks.Import(a[0], *seed, keyType))
+		checkErr(ks.Save(*file))
+	case "list":
+		args(0)
+		ks := open()
+		names, err := ks.Names()
+		checkErr(err)
+		for _, name := range names {
+			entry, err := ks.Get(name)
+			checkErr(err)
+			fmt.Printf("%s\t%s\t%s\n", name, entry.KeyType, entry.Account())
+		}
+	case "export":
+		a := args(1)
+		seed, keyType, err := open().Export(a[0])
+		checkErr(err)
+		fmt.Printf("%s\t%s\n", seed, keyType)
+	case "remove":
+		a := args(1)
+		ks := open()
+		checkErr(ks.Remove(a[0]))
+		checkErr(ks.Save(*file))
+	case "rekey":
+		args(0)
+		ks := open()
+		checkErr(ks.Rekey(getPassphrase(*newPassphrase)))
+		checkErr(ks.Save(*file))
+	default:
+		log.Fatalln("Usage: keystore [flags] create|import|list|export|remove|rekey")
+	}
+}
@@ -0,0 +1,151 @@
+// Tool to assemble a multisigned transaction from signatures made offline
+//
+// Usage:
+//
+//	multisign export < tx.json > unsigned.json
+//	multisign -keystore keys.json -key name sign unsigned.json > alice.json
+//	multisign combine alice.json bob.json > signed.json
+//	multisign verify signed.json
+//	multisign submit signed.json
+//
+// Each file holds the transaction blob, which is what is signed and
+// combined, together with its JSON for review.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/ffddw/ripple/data"
+	"github.com/ffddw/ripple/keystore"
+	"github.com/ffddw/ripple/websockets"
+)
+
+var (
+	host       = flag.String("host", "wss://s2.ripple.com:443", "websockets host")
+	keys       = flag.String("keystore", "keystore.json", "keystore file")
+	key        = flag.String("key", "", "name of the signing key in the keystore")
+	account    = flag.String("account", "", "account to sign for, if not that of the key")
+	passphrase = flag.String("passphrase", "RIPPLE_KEYSTORE_PASSPHRASE", "environment variable holding the keystore passphrase")
+)
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+type file struct {
+	TxJSON data.Transaction    `json:"tx_json"`
+	TxBlob data.VariableLength `json:"tx_blob"`
+}
+
+func read(name string) data.Transaction {
+	f, err := os.Open(name)
+	checkErr(err)
+	defer f.Close()
+	var in struct {
+		TxBlob data.VariableLength `json:"tx_blob"`
+	}
+	checkErr(json.NewDecoder(f).Decode(&in))
+	tx, err := data.ReadTransaction(bytes.NewReader(in.TxBlob))
+	checkErr(err)
+	return tx
+}
+
+func write(tx data.Transaction) {
+	_, raw, err := data.Raw(tx)
+	checkErr(err)
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "\t")
+	checkErr(enc.Encode(file{TxJSON: tx, TxBlob: raw}))
+}
+
+func multiSignable(tx data.Transaction) data.MultiSignable {
+	return tx.(data.MultiSignable)
+}
+
+func args(n int) []string {
+	if flag.NArg() < n+1 {
+		log.Fatalf("Usage: multisign [flags] %s: too few arguments", flag.Arg(0))
+	}
+	return flag.Args()[1:]
+}
+
+func verify(remote *websockets.Remote, tx data.Transaction) {
+	base := tx.GetBase()
+	list, err := remote.SignerList(base.Account, "validated")
+	checkErr(err)
+	info, err := remote.AccountInfo(base.Account, "validated")
+	checkErr(err)
+	// Signers which are not funded accounts can only use their master keys
+	var signerRoots []*data.AccountRoot
+	for _, signer := range base.Signers {
+		info, err := remote.AccountInfo(signer.Signer.Account, "validated")
+		if cmdErr, ok := err.(*websockets.CommandError); ok && cmdErr.Name == "actNotFound" {
+			continue
+		}
+		checkErr(err)
+		signerRoots = append(signerRoots, &info.AccountData)
+	}
+	_, err = data.CheckAuthority(tx, &info.AccountData, list, signerRoots...)
+	checkErr(err)
+	weight, err := data.CheckQuorum(multiSignable(tx), list)
+	checkErr(err)
+	log.Printf("Signers have weight %d of quorum %d", weight, *list.SignerQuorum)
+}
+
+func main() {
+	flag.Parse()
+	switch flag.Arg(0) {
+	case "export":
+		var txm data.TransactionWithMetaData
+		checkErr(json.NewDecoder(os.Stdin).Decode(&txm))
+		base := txm.GetBase()
+		base.SigningPubKey, base.TxnSignature, base.Signers = new(data.PublicKey), nil, nil
+		write(txm.Transaction)
+	case "sign":
+		in := args(1)
+		tx := read(in[0])
+		ks, err := keystore.Open(*keys)
+		checkErr(err)
+		checkErr(ks.Unlock(os.Getenv(*passphrase)))
+		entry, err := ks.Get(*key)
+		checkErr(err)
+		ks.Lock()
+		signFor := entry.Account()
+		if *account != "" {
+			a, err := data.NewAccountFromAddress(*account)
+			checkErr(err)
+			signFor = *a
+		}
+		checkErr(data.AddSigner(multiSignable(tx), entry.Signer(), signFor))
+		write(tx)
+	case "combine":
+		in := args(2)
+		tx := read(in[0])
+		var copies []data.MultiSignable
+		for _, name := range in[1:] {
+			copies = append(copies, multiSignable(read(name)))
+		}
+		checkErr(data.CombineSigners(multiSignable(tx), copies...))
+		write(tx)
+	case "verify", "submit":
+		in := args(1)
+		tx := read(in[0])
+		remote, err := websockets.NewRemote(*host)
+		checkErr(err)
+		verify(remote, tx)
+		if flag.Arg(0) == "submit" {
+			result, err := remote.SubmitMultisigned(tx)
+			checkErr(err)
+			fmt.Printf("%s %s\n", result.EngineResult, result.EngineResultMessage)
+		}
+	default:
+		log.Fatalln("Usage: multisign [flags] export|sign|combine|verify|submit")
+	}
+}
@@ -0,0 +1,43 @@
+// Tool to convert seeds to and from the RFC 1751 words returned as key by
+// rippled's wallet_propose
+//
+// Usage:
+//
+//	rfc1751 snoPBrXtMeMyMHUVTgbuqAfg1SUTb
+//	rfc1751 -decode I IRE BOND BOW TRIO LAID SEAT GOAL HEN IBIS IBIS DARE
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/ffddw/ripple/data"
+)
+
+var decode = flag.Bool("decode", false, "convert RFC 1751 words to a seed")
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() == 0 {
+		log.Fatalln("Usage: rfc1751 [-decode] seed|words")
+	}
+	if *decode {
+		seed, err := data.NewSeedFromRFC1751(strings.Join(flag.Args(), " "))
+		checkErr(err)
+		fmt.Println(seed)
+		return
+	}
+	for _, arg := range flag.Args() {
+		seed, err := data.NewSeedFromAddress(arg)
+		checkErr(err)
+		fmt.Println(seed.RFC1751())
+	}
+}
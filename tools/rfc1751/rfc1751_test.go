@@ -0,0 +1,2 @@
+// Empty test file to ensure rfc1751 tool compiles
+package main
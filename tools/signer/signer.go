@@ -0,0 +1,60 @@
+// Tool to serve the keys in a keystore to signer clients over a Unix socket,
+// so that the keys are only ever decrypted in this process
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/ffddw/ripple/crypto"
+	"github.com/ffddw/ripple/keystore"
+	"github.com/ffddw/ripple/signer"
+)
+
+var (
+	keys       = flag.String("keystore", "keystore.json", "keystore file")
+	passphrase = flag.String("passphrase", "RIPPLE_KEYSTORE_PASSPHRASE", "environment variable holding the keystore passphrase")
+	socket     = flag.String("socket", "signer.sock", "path of the Unix socket to listen on")
+)
+
+func checkErr(err error) {
+	if err != nil {
+		log.Fatalln(err.Error())
+	}
+}
+
+func main() {
+	flag.Parse()
+	ks, err := keystore.Open(*keys)
+	checkErr(err)
+	checkErr(ks.Unlock(os.Getenv(*passphrase)))
+	names, err := ks.Names()
+	checkErr(err)
+	signers := make(map[string]crypto.Signer)
+	for _, name := range names {
+		entry, err := ks.Get(name)
+		checkErr(err)
+		signers[name] = entry.Signer()
+		log.Printf("Serving %s for %s", name, entry.Account())
+	}
+	ks.Lock()
+	// The socket is created owner only, so that no other user can connect
+	// before its permissions could be changed
+	umask := syscall.Umask(0177)
+	l, err := net.Listen("unix", *socket)
+	syscall.Umask(umask)
+	checkErr(err)
+	kill := make(chan os.Signal, 1)
+	signal.Notify(kill, os.Interrupt)
+	go func() {
+		<-kill
+		l.Close()
+	}()
+	if err := signer.NewServer(signers).Serve(l); err != nil {
+		log.Println(err)
+	}
+}
@@ -0,0 +1,2 @@
+// Empty test file to ensure signer tool compiles
+package main
@@ -6,10 +6,13 @@ import (
 	"os"
 
 	"github.com/ffddw/ripple/config"
+	"github.com/ffddw/ripple/keystore"
 )
 
 var (
-	host = flag.String("host", "wss://s2.ripple.com:443", "websockets host")
+	host       = flag.String("host", "wss://s2.ripple.com:443", "websockets host")
+	keys       = flag.String("keystore", "", "keystore file holding the keys named by actions")
+	passphrase = flag.String("passphrase", "RIPPLE_KEYSTORE_PASSPHRASE", "environment variable holding the keystore passphrase")
 )
 
 func checkErr(err error) {
@@ -22,6 +25,13 @@ func main() {
 	flag.Parse()
 	actions, err := config.Parse(os.Stdin)
 	checkErr(err)
+	if *keys != "" {
+		ks, err := keystore.Open(*keys)
+		checkErr(err)
+		checkErr(ks.Unlock(os.Getenv(*passphrase)))
+		checkErr(actions.Resolve(ks))
+		ks.Lock()
+	}
 	checkErr(actions.Prepare())
 	checkErr(actions.Submit(*host))
 	log.Printf("Submitted %d transactions", actions.Count())
@@ -1,6 +1,8 @@
 package websockets
 
 import (
+	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"sync/atomic"
@@ -152,6 +154,27 @@ type SubmitResult struct {
 	Tx                  *SubmitResultTxJSON    `json:"tx_json"`
 }
 
+type SubmitMultisignedCommand struct {
+	*Command
+	TxJSON json.RawMessage `json:"tx_json"`
+	Result *SubmitResult   `json:"result,omitempty"`
+}
+
+// newTxJSON returns the JSON of a transaction without the hash, which
+// rippled does not accept as a field
+func newTxJSON(tx data.Transaction) (json.RawMessage, error) {
+	b, err := json.Marshal(tx)
+	if err != nil {
+		return nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b, &fields); err != nil {
+		return nil, err
+	}
+	delete(fields, "hash")
+	return json.Marshal(fields)
+}
+
 type LedgerCommand struct {
 	*Command
 	LedgerIndex  interface{}   `json:"ledger_index"`
@@ -205,6 +228,17 @@ type BinaryLedgerData struct {
 	Index string `json:"index"`
 }
 
+// LedgerEntry decodes the entry, also returning the bytes it was decoded
+// from, which are nil if the hex was invalid.
+func (b *BinaryLedgerData) LedgerEntry() (data.LedgerEntry, []byte, error) {
+	raw, err := hex.DecodeString(b.Data + b.Index)
+	if err != nil {
+		return nil, nil, err
+	}
+	le, err := data.ReadLedgerEntry(bytes.NewReader(raw), data.Hash256{})
+	return le, raw, err
+}
+
 type BinaryLedgerDataResult struct {
 	LedgerSequence uint32             `json:"ledger_index"`
 	Hash           data.Hash256       `json:"ledger_hash"`
@@ -212,6 +246,29 @@ type BinaryLedgerDataResult struct {
 	State          []BinaryLedgerData `json:"state"`
 }
 
+type LedgerEntryCommand struct {
+	*Command
+	Index       data.Hash256       `json:"index"`
+	LedgerIndex interface{}        `json:"ledger_index,omitempty"`
+	Binary      bool               `json:"binary"`
+	Result      *LedgerEntryResult `json:"result,omitempty"`
+}
+
+type LedgerEntryResult struct {
+	LedgerSequence uint32       `json:"ledger_index"`
+	Index          data.Hash256 `json:"index"`
+	Node           string       `json:"node_binary"`
+}
+
+func (l *LedgerEntryResult) LedgerEntry() (data.LedgerEntry, error) {
+	raw, err := hex.DecodeString(l.Node)
+	if err != nil {
+		return nil, err
+	}
+	raw = append(raw, l.Index[:]...)
+	return data.ReadLedgerEntry(bytes.NewReader(raw), data.Hash256{})
+}
+
 type RipplePathFindCommand struct {
 	*Command
 	SrcAccount    data.Account          `json:"source_account"`
@@ -383,3 +440,12 @@ type ServerStateResult struct {
 		ValidationQuorum int `json:"validation_quorum"`
 	} `json:"state"`
 }
+
+// Reserve returns the reserve of the validated ledger, which server_state
+// gives in drops
+func (r *ServerStateResult) Reserve() data.Reserve {
+	return data.Reserve{
+		Base:      uint64(r.State.ValidatedLedger.ReserveBase),
+		Increment: uint64(r.State.ValidatedLedger.ReserveInc),
+	}
+}
@@ -2,10 +2,12 @@ package websockets
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"testing"
 
 	"github.com/ffddw/ripple/data"
+	internal "github.com/ffddw/ripple/testing"
 	. "gopkg.in/check.v1"
 )
 
@@ -229,6 +231,7 @@ func (s *MessagesSuite) TestServerStateResponse(c *C) {
 	c.Assert(msg.Result.State.Uptime, Equals, 270655)
 	c.Assert(msg.Result.State.ValidatedLedger.CloseTime, Equals, 792148241)
 	c.Assert(msg.Result.State.ValidatedLedger.Hash, Equals, "0A6DFE7685BB62B5D434DDF8939DA3C617C6988BC9BF65409DB4BE035A7C1001")
+	c.Assert(msg.Result.Reserve(), Equals, data.Reserve{Base: 1000000, Increment: 200000})
 }
 
 func (s *MessagesSuite) TestSubmitResultResponse(c *C) {
@@ -250,3 +253,27 @@ func (s *MessagesSuite) TestSubmitResultResponse(c *C) {
 	c.Assert(msg.Result.Tx.TxnSignature, Equals, "3045022100BDE09A1F6670403F341C21A77CF35BA47E45CDE974096E1AA5FC39811D8269E702203D60291B9A27F1DCABA9CF5DED307B4F23223E0B6F156991DB601DFB9C41CE1C")
 	c.Assert(msg.Result.Tx.Hash, Equals, "02ACE87F1996E3A23690A5BB7F1774BF71CCBA68F79805831B42ABAD5913D6F4")
 }
+
+func (s *MessagesSuite) TestBinaryLedgerData(c *C) {
+	var node internal.TestData
+	for _, test := range internal.Nodes {
+		if test.Description == "AccountRoot" {
+			node = test
+		}
+	}
+	// Strip the nodestore header and hash prefix
+	state := BinaryLedgerData{
+		Data:  node.Encoded[26 : len(node.Encoded)-64],
+		Index: node.Encoded[len(node.Encoded)-64:],
+	}
+	le, _, err := state.LedgerEntry()
+	c.Assert(err, IsNil)
+	c.Assert(le.GetLedgerEntryType(), Equals, data.ACCOUNT_ROOT)
+
+	state.Data = state.Data[:20]
+	_, raw, err := state.LedgerEntry()
+	var decodeErr *data.DecodeError
+	c.Assert(errors.As(err, &decodeErr), Equals, true)
+	c.Assert(decodeErr.Offset < len(raw), Equals, true)
+	c.Assert(decodeErr.Path, Not(HasLen), 0)
+}
@@ -0,0 +1,112 @@
+package websockets
+
+import (
+	"fmt"
+	"math/bits"
+
+	"github.com/ffddw/ripple/data"
+)
+
+// FeeLevel chooses which of the fee levels of a FeeResult to pay
+type FeeLevel int
+
+const (
+	// OpenLedgerFeeLevel gets a transaction into the open ledger without
+	// waiting in the queue
+	OpenLedgerFeeLevel FeeLevel = iota
+	// MedianFeeLevel is the median level of transactions in the last ledger
+	MedianFeeLevel
+	// MinimumFeeLevel is the least that the queue will accept, so the
+	// transaction may wait for a later ledger
+	MinimumFeeLevel
+)
+
+// FeeCalculator works out the Fee of transactions from the fee levels of a
+// FeeResult and the base fee and owner reserve of the ledger's FeeSettings.
+// A fee level is relative to the reference level, at which a transaction
+// pays its unscaled cost.
+type FeeCalculator struct {
+	// Level is the fee level to pay
+	Level FeeLevel
+	// Max is the most to pay in drops, or zero for no limit. A fee above
+	// Max is reduced to Max if that still reaches the minimum level.
+	Max uint64
+
+	base, increment uint64
+	levels          [3]uint64
+	reference       uint64
+}
+
+func NewFeeCalculator(result *FeeResult, settings *data.FeeSettings) (*FeeCalculator, error) {
+	base, _, increment, err := settings.Drops()
+	if err != nil {
+		return nil, err
+	}
+	c := &FeeCalculator{base: base, increment: increment}
+	for _, v := range []struct {
+		value *data.Value
+		level *uint64
+	}{
+		{&result.Levels.OpenLedgerLevel, &c.levels[OpenLedgerFeeLevel]},
+		{&result.Levels.MedianLevel, &c.levels[MedianFeeLevel]},
+		{&result.Levels.MinimumLevel, &c.levels[MinimumFeeLevel]},
+		{&result.Levels.ReferenceLevel, &c.reference},
+	} {
+		rat := v.value.Rat()
+		if !rat.IsInt() || rat.Sign() < 0 || !rat.Num().IsUint64() {
+			return nil, fmt.Errorf("Bad fee level: %s", v.value)
+		}
+		*v.level = rat.Num().Uint64()
+	}
+	if c.reference == 0 {
+		return nil, fmt.Errorf("Zero reference fee level")
+	}
+	return c, nil
+}
+
+// Fee returns the fee in drops for tx, which will carry the signatures of
+// signers if it is multisigned
+func (c *FeeCalculator) Fee(tx data.Transaction, signers int) (uint64, error) {
+	cost := data.TransactionFee(tx, c.base, c.increment, signers)
+	fee, err := scaleFee(cost, c.levels[c.Level], c.reference)
+	if err != nil {
+		return 0, err
+	}
+	if c.Max == 0 || fee <= c.Max {
+		return fee, nil
+	}
+	minimum, err := scaleFee(cost, c.levels[MinimumFeeLevel], c.reference)
+	if err != nil {
+		return 0, err
+	}
+	if minimum > c.Max {
+		return 0, fmt.Errorf("Minimum fee of %d drops exceeds maximum: %d", minimum, c.Max)
+	}
+	return c.Max, nil
+}
+
+// SetFee sets the Fee of tx
+func (c *FeeCalculator) SetFee(tx data.Transaction, signers int) error {
+	fee, err := c.Fee(tx, signers)
+	if err != nil {
+		return err
+	}
+	value, err := data.NewNativeValue(int64(fee))
+	if err != nil {
+		return err
+	}
+	tx.GetBase().Fee = *value
+	return nil
+}
+
+// scaleFee returns cost*level/reference rounded up
+func scaleFee(cost, level, reference uint64) (uint64, error) {
+	hi, lo := bits.Mul64(cost, level)
+	lo, carry := bits.Add64(lo, reference-1, 0)
+	hi += carry
+	if hi >= reference {
+		return 0, fmt.Errorf("Fee overflow")
+	}
+	fee, _ := bits.Div64(hi, lo, reference)
+	return fee, nil
+}
@@ -0,0 +1,49 @@
+package websockets
+
+import (
+	"github.com/ffddw/ripple/data"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MessagesSuite) TestFeeCalculator(c *C) {
+	msg := &FeeCommand{}
+	readResponseFile(c, msg, "testdata/fee.json")
+	c.Assert(msg.Status, Equals, "success")
+
+	base, reserve, increment := data.Uint64Hex(10), uint32(10000000), uint32(2000000)
+	settings := &data.FeeSettings{BaseFee: &base, ReserveBase: &reserve, ReserveIncrement: &increment}
+	calc, err := NewFeeCalculator(msg.Result, settings)
+	c.Assert(err, IsNil)
+
+	_, fulfillment, err := data.NewPreimageSha256(make([]byte, 32))
+	c.Assert(err, IsNil)
+	escrowFinish := &data.EscrowFinish{Fulfillment: (*data.VariableLength)(&fulfillment)}
+	for _, test := range []struct {
+		tx      data.Transaction
+		signers int
+		level   FeeLevel
+		fee     uint64
+	}{
+		{&data.Payment{}, 0, OpenLedgerFeeLevel, 100},
+		{&data.Payment{}, 0, MinimumFeeLevel, 10},
+		{&data.Payment{}, 0, MedianFeeLevel, 5000},
+		{&data.Payment{}, 3, MinimumFeeLevel, 40},
+		{escrowFinish, 0, MinimumFeeLevel, 350},
+		{escrowFinish, 1, MinimumFeeLevel, 360},
+		{&data.AccountDelete{}, 0, MinimumFeeLevel, 2000000},
+		{&data.AMMCreate{}, 0, OpenLedgerFeeLevel, 20000000},
+	} {
+		calc.Level = test.level
+		fee, err := calc.Fee(test.tx, test.signers)
+		c.Check(err, IsNil)
+		c.Check(fee, Equals, test.fee, Commentf("%T %d %d", test.tx, test.signers, test.level))
+	}
+
+	calc.Level, calc.Max = OpenLedgerFeeLevel, 50
+	payment := &data.Payment{}
+	c.Assert(calc.SetFee(payment, 0), IsNil)
+	c.Check(payment.Fee.String(), Equals, "0.00005")
+	calc.Max = 5
+	_, err = calc.Fee(payment, 0)
+	c.Check(err, ErrorMatches, "Minimum fee of 10 drops exceeds maximum: 5")
+}
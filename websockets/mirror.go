@@ -0,0 +1,329 @@
+package websockets
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ffddw/ripple/data"
+	"github.com/golang/glog"
+)
+
+// Mirror is an in-memory copy of the entire state of the validated ledger.
+// It is loaded with StreamLedgerData and then kept up to date by applying
+// the metadata of each following ledger's transactions from the ledger and
+// transaction streams. After every ledger the hash of the copy is compared
+// with the ledger's account_hash.
+//
+// Metadata never records the Indexes of a DirectoryNode, so the directories
+// which a ledger changes are fetched from the server. If the hash still
+// differs, every entry which the ledger changed is fetched before the
+// Mirror reports a Divergence.
+type Mirror struct {
+	// Diverged is called, if set, with each ledger whose account_hash the
+	// Mirror fails to reproduce
+	Diverged func(*Divergence)
+
+	remote *Remote
+	mu     sync.RWMutex
+	state  data.State
+	tree   *data.HashTree
+	ledger uint32
+}
+
+// Divergence is a ledger whose account_hash differs from the hash of the
+// mirrored state
+type Divergence struct {
+	LedgerSequence uint32
+	StateHash      data.Hash256
+	MirrorHash     data.Hash256
+}
+
+func (d *Divergence) Error() string {
+	return fmt.Sprintf("Ledger %d has state hash %s but mirror has %s", d.LedgerSequence, d.StateHash, d.MirrorHash)
+}
+
+func NewMirror(remote *Remote) *Mirror {
+	return &Mirror{remote: remote}
+}
+
+// Run subscribes to the ledger and transaction streams, loads the state of
+// the validated ledger and then applies each following validated ledger
+// until the Remote is closed. Run consumes all Incoming messages of the
+// Remote.
+func (m *Mirror) Run() error {
+	sub, err := m.remote.Subscribe(true, true, false, false)
+	if err != nil {
+		return err
+	}
+	ledgers := collectLedgers(m.remote.Incoming)
+	if err := m.load(sub.LedgerSequence); err != nil {
+		return err
+	}
+	for l := range ledgers {
+		// Fetch any ledgers which the streams did not complete
+		for sequence := m.Ledger() + 1; sequence < l.LedgerSequence; sequence++ {
+			missed, err := m.remote.Ledger(sequence, true)
+			if err != nil {
+				return err
+			}
+			if err := m.advance(&missed.Ledger); err != nil {
+				return err
+			}
+		}
+		if l.LedgerSequence != m.Ledger()+1 {
+			continue
+		}
+		if err := m.advance(l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Ledger returns the sequence of the mirrored ledger
+func (m *Mirror) Ledger() uint32 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.ledger
+}
+
+// Entry returns the entry with index, or nil if there is none
+func (m *Mirror) Entry(index data.Hash256) data.LedgerEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.state[index]
+}
+
+// View calls f with the mirrored state, which f must neither change nor
+// keep. The Mirror waits for f to return before applying the next ledger.
+func (m *Mirror) View(f func(ledger uint32, state data.State)) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	f(m.ledger, m.state)
+}
+
+func (m *Mirror) load(ledger uint32) error {
+	header, err := m.remote.Ledger(ledger, false)
+	if err != nil {
+		return err
+	}
+	state := make(data.State)
+	// The stream must be drained even after an error
+	for les := range m.remote.StreamLedgerData(ledger) {
+		for _, le := range les {
+			if err == nil {
+				err = state.Add(le)
+			}
+		}
+	}
+	if err != nil {
+		return err
+	}
+	tree, err := state.Tree()
+	if err != nil {
+		return err
+	}
+	hash, err := tree.Hash()
+	if err != nil {
+		return err
+	}
+	if hash != header.Ledger.StateHash {
+		return &Divergence{ledger, header.Ledger.StateHash, hash}
+	}
+	m.mu.Lock()
+	m.state, m.tree, m.ledger = state, tree, ledger
+	m.mu.Unlock()
+	return nil
+}
+
+// advance applies l, which must be the ledger after the mirrored one
+func (m *Mirror) advance(l *data.Ledger) error {
+	header, err := m.remote.Ledger(l.LedgerSequence, false)
+	if err != nil {
+		return err
+	}
+	indexes, directories := affected(l)
+	les, err := m.remote.LedgerEntries(directories, l.LedgerSequence)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	hash, err := m.apply(l, indexes, les)
+	m.mu.Unlock()
+	if err != nil || hash == header.Ledger.StateHash {
+		return err
+	}
+	les, err = m.remote.LedgerEntries(indexes, l.LedgerSequence)
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	hash, err = m.replace(indexes, les)
+	m.mu.Unlock()
+	if err != nil || hash == header.Ledger.StateHash {
+		return err
+	}
+	divergence := &Divergence{l.LedgerSequence, header.Ledger.StateHash, hash}
+	glog.Errorln(divergence.Error())
+	if m.Diverged != nil {
+		m.Diverged(divergence)
+	}
+	return nil
+}
+
+// apply applies the metadata of l and then the directories fetched from
+// the server, and returns the new hash of the state
+func (m *Mirror) apply(l *data.Ledger, indexes []data.Hash256, directories data.LedgerEntrySlice) (data.Hash256, error) {
+	if err := m.state.ApplyLedger(l); err != nil {
+		return data.Hash256{}, err
+	}
+	m.ledger = l.LedgerSequence
+	for _, le := range directories {
+		if err := m.state.Add(le); err != nil {
+			return data.Hash256{}, err
+		}
+	}
+	return m.update(indexes)
+}
+
+// replace replaces the entries at indexes with les, which are all of those
+// that exist, and returns the new hash of the state
+func (m *Mirror) replace(indexes []data.Hash256, les data.LedgerEntrySlice) (data.Hash256, error) {
+	for _, index := range indexes {
+		delete(m.state, index)
+	}
+	for _, le := range les {
+		if err := m.state.Add(le); err != nil {
+			return data.Hash256{}, err
+		}
+	}
+	return m.update(indexes)
+}
+
+func (m *Mirror) update(indexes []data.Hash256) (data.Hash256, error) {
+	for _, index := range indexes {
+		le, ok := m.state[index]
+		if !ok {
+			m.tree.Delete(index)
+			continue
+		}
+		hash, err := data.LeafHash(le)
+		if err != nil {
+			return data.Hash256{}, err
+		}
+		m.tree.Set(index, hash)
+	}
+	return m.tree.Hash()
+}
+
+// affected returns the indexes of the entries which the transactions of l
+// change, and separately those which are directories
+func affected(l *data.Ledger) (indexes, directories []data.Hash256) {
+	seen := make(map[data.Hash256]bool)
+	for _, txm := range l.Transactions {
+		for i := range txm.MetaData.AffectedNodes {
+			node, _, _, _ := txm.MetaData.AffectedNodes[i].AffectedNode()
+			if node.LedgerIndex == nil || seen[*node.LedgerIndex] {
+				continue
+			}
+			seen[*node.LedgerIndex] = true
+			indexes = append(indexes, *node.LedgerIndex)
+			if node.LedgerEntryType == data.DIRECTORY {
+				directories = append(directories, *node.LedgerIndex)
+			}
+		}
+	}
+	return indexes, directories
+}
+
+// ledgerCollector assembles validated ledgers from the messages of the
+// ledger and transaction streams
+type ledgerCollector map[uint32]*pendingLedger
+
+type pendingLedger struct {
+	closed *LedgerStreamMsg
+	txns   data.TransactionSlice
+}
+
+// add returns the ledger which msg completes, if any
+func (c ledgerCollector) add(msg interface{}) *data.Ledger {
+	var sequence uint32
+	switch msg := msg.(type) {
+	case *LedgerStreamMsg:
+		sequence = msg.LedgerSequence
+		c.get(sequence).closed = msg
+	case *TransactionStreamMsg:
+		if !msg.Validated {
+			return nil
+		}
+		sequence = msg.LedgerSequence
+		msg.Transaction.LedgerSequence = sequence
+		p := c.get(sequence)
+		p.txns = append(p.txns, &msg.Transaction)
+	default:
+		return nil
+	}
+	p := c[sequence]
+	if p.closed == nil || uint32(len(p.txns)) < p.closed.TxnCount {
+		return nil
+	}
+	// Earlier ledgers which are still incomplete have missed messages
+	for s := range c {
+		if s <= sequence {
+			delete(c, s)
+		}
+	}
+	p.txns.Sort()
+	return &data.Ledger{
+		LedgerHeader: data.LedgerHeader{LedgerSequence: sequence},
+		Hash:         p.closed.LedgerHash,
+		Closed:       true,
+		Accepted:     true,
+		Transactions: p.txns,
+	}
+}
+
+func (c ledgerCollector) get(sequence uint32) *pendingLedger {
+	p, ok := c[sequence]
+	if !ok {
+		p = &pendingLedger{}
+		c[sequence] = p
+	}
+	return p
+}
+
+// collectLedgers returns the ledgers assembled from incoming in order of
+// completion. Incoming is always read, however slowly the ledgers are
+// received, so that responses to commands are never held up.
+func collectLedgers(incoming chan interface{}) chan *data.Ledger {
+	out := make(chan *data.Ledger)
+	go func() {
+		defer close(out)
+		var (
+			collector = make(ledgerCollector)
+			queue     []*data.Ledger
+		)
+		for incoming != nil || len(queue) > 0 {
+			var (
+				send chan *data.Ledger
+				next *data.Ledger
+			)
+			if len(queue) > 0 {
+				send, next = out, queue[0]
+			}
+			select {
+			case msg, ok := <-incoming:
+				if !ok {
+					incoming = nil
+					continue
+				}
+				if l := collector.add(msg); l != nil {
+					queue = append(queue, l)
+				}
+			case send <- next:
+				queue = queue[1:]
+			}
+		}
+	}()
+	return out
+}
@@ -0,0 +1,38 @@
+package websockets
+
+import (
+	"github.com/ffddw/ripple/data"
+	. "gopkg.in/check.v1"
+)
+
+func (s *MessagesSuite) TestLedgerCollector(c *C) {
+	closed := streamMessageFactory["ledgerClosed"]().(*LedgerStreamMsg)
+	readResponseFile(c, closed, "testdata/ledger_stream.json")
+	tx := streamMessageFactory["transaction"]().(*TransactionStreamMsg)
+	readResponseFile(c, tx, "testdata/transactions_stream.json")
+	tx.LedgerSequence = closed.LedgerSequence
+
+	collector := make(ledgerCollector)
+	c.Assert(collector.add(&ServerStreamMsg{}), IsNil)
+	c.Assert(collector.add(closed), IsNil)
+	l := collector.add(tx)
+	c.Assert(l, NotNil)
+	c.Assert(l.LedgerSequence, Equals, uint32(6959229))
+	c.Assert(l.Hash.String(), Equals, "21EB30937A47EA6B71B63183806FFE9308CCB786137AA00FFB32A7094C6426FA")
+	c.Assert(l.Transactions, HasLen, 1)
+	c.Assert(l.Transactions[0].LedgerSequence, Equals, uint32(6959229))
+	c.Assert(collector, HasLen, 0)
+
+	// An empty ledger completes at once and drops an earlier one which
+	// missed its transactions
+	c.Assert(collector.add(&LedgerStreamMsg{LedgerSequence: 6959230, TxnCount: 2}), IsNil)
+	c.Assert(collector.add(&TransactionStreamMsg{LedgerSequence: 6959231}), IsNil)
+	l = collector.add(&LedgerStreamMsg{LedgerSequence: 6959232})
+	c.Assert(l, NotNil)
+	c.Assert(l.Transactions, HasLen, 0)
+	c.Assert(collector, HasLen, 0)
+
+	indexes, directories := affected(&data.Ledger{Transactions: data.TransactionSlice{&tx.Transaction}})
+	c.Assert(indexes, HasLen, 7)
+	c.Assert(directories, HasLen, 4)
+}
@@ -1,8 +1,6 @@
 package websockets
 
 import (
-	"bytes"
-	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net"
@@ -222,6 +220,24 @@ func (r *Remote) Submit(tx data.Transaction) (*SubmitResult, error) {
 	return cmd.Result, nil
 }
 
+// Synchronously submit a transaction with all of its Signers
+func (r *Remote) SubmitMultisigned(tx data.Transaction) (*SubmitResult, error) {
+	txJSON, err := newTxJSON(tx)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &SubmitMultisignedCommand{
+		Command: newCommand("submit_multisigned"),
+		TxJSON:  txJSON,
+	}
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, cmd.CommandError
+	}
+	return cmd.Result, nil
+}
+
 // Synchronously submit multiple transactions
 func (r *Remote) SubmitBatch(txs []data.Transaction) ([]*SubmitResult, error) {
 	commands := make([]*SubmitCommand, len(txs))
@@ -260,6 +276,27 @@ func (r *Remote) LedgerData(ledger interface{}, marker *data.Hash256) (*LedgerDa
 	return cmd.Result, nil
 }
 
+// Synchronously gets ledger entries in binary form and decodes them. The
+// marker for the next page is returned, which is nil on the last page. A
+// failure to decode an entry returns an error wrapping a data.DecodeError.
+func (r *Remote) BinaryLedgerData(ledger interface{}, marker *data.Hash256) (data.LedgerEntrySlice, *data.Hash256, error) {
+	cmd := newBinaryLedgerDataCommand(ledger, marker)
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, nil, cmd.CommandError
+	}
+	les := make(data.LedgerEntrySlice, 0, len(cmd.Result.State))
+	for _, state := range cmd.Result.State {
+		le, _, err := state.LedgerEntry()
+		if err != nil {
+			return nil, nil, fmt.Errorf("Ledger entry %s: %w", state.Index, err)
+		}
+		les = append(les, le)
+	}
+	return les, cmd.Result.Marker, nil
+}
+
 func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan data.LedgerEntrySlice, wg *sync.WaitGroup) {
 	defer wg.Done()
 	first, err := data.NewHash256(start)
@@ -267,7 +304,6 @@ func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan
 		glog.Errorln(err.Error())
 	}
 	cmd := newBinaryLedgerDataCommand(ledger, first)
-	var br bytes.Reader
 	for ; ; cmd = newBinaryLedgerDataCommand(ledger, cmd.Result.Marker) {
 		r.outgoing <- cmd
 		<-cmd.Ready
@@ -281,17 +317,15 @@ func (r *Remote) streamLedgerData(ledger interface{}, start, end string, c chan
 			if done = state.Index > end; done {
 				break
 			}
-			b, err := hex.DecodeString(state.Data + state.Index)
-			if err != nil {
+			le, b, err := state.LedgerEntry()
+			switch {
+			case b == nil:
 				glog.Errorln(err.Error())
 				return
-			}
-			br.Reset(b)
-			le, err := data.ReadLedgerEntry(&br, data.Hash256{})
-			if err != nil {
+			case err != nil:
 				glog.Errorln(err.Error())
-				glog.Errorln(state.Data)
 				glog.Errorln(state.Index)
+				glog.Errorln(data.HexDump(b, err))
 				continue
 			}
 			les = append(les, le)
@@ -350,6 +384,88 @@ func (r *Remote) LedgerHeader(ledger interface{}) (*LedgerHeaderResult, error) {
 	return cmd.Result, nil
 }
 
+// Synchronously requests a single ledger entry
+func (r *Remote) LedgerEntry(index data.Hash256, ledgerIndex interface{}) (data.LedgerEntry, error) {
+	cmd := &LedgerEntryCommand{
+		Command:     newCommand("ledger_entry"),
+		Index:       index,
+		LedgerIndex: ledgerIndex,
+		Binary:      true,
+	}
+	r.outgoing <- cmd
+	<-cmd.Ready
+	if cmd.CommandError != nil {
+		return nil, cmd.CommandError
+	}
+	return cmd.Result.LedgerEntry()
+}
+
+// Synchronously requests many ledger entries at once. Entries which do not
+// exist in the ledger are left out.
+func (r *Remote) LedgerEntries(indexes []data.Hash256, ledgerIndex interface{}) (data.LedgerEntrySlice, error) {
+	commands := make([]*LedgerEntryCommand, len(indexes))
+	for i := range indexes {
+		commands[i] = &LedgerEntryCommand{
+			Command:     newCommand("ledger_entry"),
+			Index:       indexes[i],
+			LedgerIndex: ledgerIndex,
+			Binary:      true,
+		}
+		r.outgoing <- commands[i]
+	}
+	var (
+		les      data.LedgerEntrySlice
+		firstErr error
+	)
+	for _, cmd := range commands {
+		<-cmd.Ready
+		switch {
+		case firstErr != nil:
+		case cmd.CommandError != nil && cmd.CommandError.Name == "entryNotFound":
+		case cmd.CommandError != nil:
+			firstErr = cmd.CommandError
+		default:
+			le, err := cmd.Result.LedgerEntry()
+			if err != nil {
+				firstErr = fmt.Errorf("Ledger entry %s: %w", cmd.Index, err)
+				continue
+			}
+			les = append(les, le)
+		}
+	}
+	return les, firstErr
+}
+
+// Synchronously requests the signer list of an account
+func (r *Remote) SignerList(account data.Account, ledgerIndex interface{}) (*data.SignerList, error) {
+	index, err := data.GetSignerListIndex(account)
+	if err != nil {
+		return nil, err
+	}
+	le, err := r.LedgerEntry(*index, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := le.(*data.SignerList)
+	if !ok {
+		return nil, fmt.Errorf("Expected SignerList got: %s", le.GetType())
+	}
+	return list, nil
+}
+
+// PayChannel returns the ledger state of a payment channel
+func (r *Remote) PayChannel(channel data.Hash256, ledgerIndex interface{}) (*data.PayChannel, error) {
+	le, err := r.LedgerEntry(channel, ledgerIndex)
+	if err != nil {
+		return nil, err
+	}
+	pc, ok := le.(*data.PayChannel)
+	if !ok {
+		return nil, fmt.Errorf("Expected PayChannel got: %s", le.GetType())
+	}
+	return pc, nil
+}
+
 // Synchronously requests paths
 func (r *Remote) RipplePathFind(src, dest data.Account, amount data.Amount, srcCurr *[]data.Currency) (*RipplePathFindResult, error) {
 	cmd := &RipplePathFindCommand{
@@ -382,6 +498,20 @@ func (r *Remote) AccountInfo(a data.Account, ledgerIndex interface{}) (*AccountI
 	return cmd.Result, nil
 }
 
+// AccountReserve returns the reserve and spendable XRP of an account in the
+// validated ledger after it owns extra more objects
+func (r *Remote) AccountReserve(account data.Account, extra int) (*data.AccountReserve, error) {
+	state, err := r.ServerState()
+	if err != nil {
+		return nil, err
+	}
+	info, err := r.AccountInfo(account, "validated")
+	if err != nil {
+		return nil, err
+	}
+	return state.Reserve().Account(&info.AccountData, extra)
+}
+
 // Synchronously requests account line info
 func (r *Remote) AccountLines(account data.Account, ledgerIndex interface{}) (*AccountLinesResult, error) {
 	var (
@@ -533,6 +663,28 @@ func (r *Remote) Fee() (*FeeResult, error) {
 	return cmd.Result, nil
 }
 
+// FeeCalculator returns a FeeCalculator for the current fee levels and the
+// FeeSettings of the validated ledger
+func (r *Remote) FeeCalculator() (*FeeCalculator, error) {
+	result, err := r.Fee()
+	if err != nil {
+		return nil, err
+	}
+	index, err := data.GetFeeIndex()
+	if err != nil {
+		return nil, err
+	}
+	le, err := r.LedgerEntry(*index, "validated")
+	if err != nil {
+		return nil, err
+	}
+	settings, ok := le.(*data.FeeSettings)
+	if !ok {
+		return nil, fmt.Errorf("Expected FeeSettings got: %s", le.GetType())
+	}
+	return NewFeeCalculator(result, settings)
+}
+
 // readPump reads from the websocket and sends to inbound channel.
 // Expects to receive PONGs at specified interval, or logs an error and returns.
 func (r *Remote) readPump(inbound chan<- []byte) {